@@ -3,9 +3,45 @@ data "tabular_warehouse" "test" {
 }
 
 data "tabular_compute_config" "test" {
-	warehouse_id = tabular_warehouse.test.id
+  warehouse_id = data.tabular_warehouse.test.id
+}
+
+data "tabular_compute_config" "by_name" {
+  warehouse_name = "Example-Warehouse"
+  ignore_case    = true
 }
 
 output "spark_config" {
   value = data.tabular_compute_config.test.spark_config
 }
+
+data "tabular_compute_config" "trino" {
+  warehouse_id = data.tabular_warehouse.test.id
+  engine       = "trino"
+}
+
+output "trino_catalog_properties" {
+  value = data.tabular_compute_config.trino.trino_catalog_properties
+}
+
+data "tabular_role" "etl" {
+  name = "etl"
+}
+
+resource "tabular_service_account" "etl" {
+  name    = "etl"
+  role_id = data.tabular_role.etl.id
+}
+
+data "tabular_compute_config" "service_account" {
+  warehouse_id      = data.tabular_warehouse.test.id
+  auth_mode         = "service_account"
+  credential_key    = tabular_service_account.etl.credential_key
+  credential_secret = tabular_service_account.etl.credential_secret
+  catalog_name      = "tabular"
+}
+
+output "spark_defaults" {
+  value     = data.tabular_compute_config.service_account.with_credential.spark_defaults
+  sensitive = true
+}
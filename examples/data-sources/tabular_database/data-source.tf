@@ -0,0 +1,13 @@
+data "tabular_database" "sales" {
+  warehouse_name = "production"
+  name           = "sales"
+  grant_roles    = ["analysts"]
+}
+
+output "sales_location" {
+  value = data.tabular_database.sales.location
+}
+
+output "analyst_sales_privileges" {
+  value = [for g in data.tabular_database.sales.grants : g.privilege]
+}
@@ -0,0 +1,13 @@
+data "tabular_databases" "raw" {
+  warehouse_id = "00000000-0000-0000-0000-000000000000"
+  name_prefix  = "raw_"
+}
+
+resource "tabular_role_database_grants" "ingest" {
+  for_each = toset(data.tabular_databases.raw.names)
+
+  warehouse_id = "00000000-0000-0000-0000-000000000000"
+  database     = each.value
+  role_name    = "ingest"
+  privileges   = ["LIST_TABLES", "CREATE_TABLE"]
+}
@@ -0,0 +1,26 @@
+variable "hr_emails" {
+  type        = set(string)
+  description = "Emails of current employees, from the HR system"
+}
+
+data "tabular_org_members" "employees" {
+  email_domains = ["example.com"]
+}
+
+output "departed_members" {
+  value = setsubtract(data.tabular_org_members.employees.emails, var.hr_emails)
+}
+
+resource "tabular_role_membership" "everyone" {
+  role_name = "everyone"
+  members   = setintersection(data.tabular_org_members.employees.emails, var.hr_emails)
+}
+
+data "tabular_org_members" "with_roles" {
+  email_domains = ["example.com"]
+  include_roles = true
+}
+
+output "members_without_roles" {
+  value = [for m in data.tabular_org_members.with_roles.members : m.email if length(m.roles) == 0]
+}
@@ -1,3 +1,21 @@
 data "tabular_role" "example" {
   name = "Example Role"
-}
\ No newline at end of file
+}
+
+data "tabular_role" "analysts" {
+  name            = "analysts"
+  include_parents = true
+  warehouse_ids   = ["00000000-0000-0000-0000-000000000000"]
+}
+
+output "analyst_admins" {
+  value = data.tabular_role.analysts.admin_members
+}
+
+output "analyst_databases" {
+  value = distinct([for g in data.tabular_role.analysts.database_grants : g.database])
+}
+
+output "analyst_parents" {
+  value = data.tabular_role.analysts.parents
+}
@@ -0,0 +1,12 @@
+data "tabular_role_effective_privileges" "analysts" {
+  role_name    = "analysts"
+  warehouse_id = "00000000-0000-0000-0000-000000000000"
+  databases    = ["sales"]
+}
+
+output "analyst_sales_privileges" {
+  value = [
+    for p in data.tabular_role_effective_privileges.analysts.privileges :
+    "${p.privilege} via ${join(" > ", p.path)}" if p.database == "sales"
+  ]
+}
@@ -0,0 +1,19 @@
+data "tabular_role_graph" "engineering" {
+  roles         = ["engineering"]
+  warehouse_ids = ["00000000-0000-0000-0000-000000000000"]
+}
+
+output "engineering_roles_mermaid" {
+  value = data.tabular_role_graph.engineering.mermaid
+}
+
+output "engineering_roles_dot" {
+  value = data.tabular_role_graph.engineering.dot
+}
+
+# Without roles, every role in the org is walked
+data "tabular_role_graph" "org" {}
+
+output "org_roles_json" {
+  value = data.tabular_role_graph.org.json
+}
@@ -0,0 +1,11 @@
+data "tabular_roles" "teams" {
+  name_prefix = "team-"
+}
+
+resource "tabular_role_warehouse_grants" "team_usage" {
+  for_each = { for r in data.tabular_roles.teams.roles : r.name => r.id }
+
+  role_id      = each.value
+  warehouse_id = "00000000-0000-0000-0000-000000000000"
+  privileges   = ["FUTURE_LIST_TABLES"]
+}
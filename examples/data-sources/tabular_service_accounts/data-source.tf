@@ -0,0 +1,7 @@
+data "tabular_service_accounts" "etl" {
+  name_regex = "(?i)etl"
+}
+
+output "etl_service_account_keys" {
+  value = [for s in data.tabular_service_accounts.etl.service_accounts : s.key]
+}
@@ -0,0 +1,5 @@
+data "tabular_storage_profiles" "all" {}
+
+output "storage_buckets" {
+  value = [for p in data.tabular_storage_profiles.all.storage_profiles : p.name]
+}
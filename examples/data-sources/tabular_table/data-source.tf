@@ -0,0 +1,13 @@
+data "tabular_table" "events" {
+  warehouse_id = "00000000-0000-0000-0000-000000000000"
+  database     = "analytics"
+  name         = "events"
+}
+
+output "events_columns" {
+  value = { for c in data.tabular_table.events.columns : c.name => c.type }
+}
+
+output "events_location" {
+  value = data.tabular_table.events.location
+}
@@ -0,0 +1,14 @@
+data "tabular_tables" "analytics" {
+  warehouse_id       = "00000000-0000-0000-0000-000000000000"
+  database           = "analytics"
+  name_prefix        = "fct_"
+  include_views      = true
+  include_properties = true
+}
+
+output "analytics_table_owners" {
+  value = {
+    for t in data.tabular_tables.analytics.tables :
+    t.identifier => lookup(t.properties, "owner", null)
+  }
+}
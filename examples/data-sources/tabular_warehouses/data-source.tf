@@ -0,0 +1,7 @@
+data "tabular_warehouses" "production" {
+  name_regex = "^prod-"
+}
+
+output "production_warehouses" {
+  value = zipmap(data.tabular_warehouses.production.names, data.tabular_warehouses.production.ids)
+}
@@ -0,0 +1,8 @@
+variable "organization_id" {
+  type = string
+}
+
+resource "aws_iam_role" "tabular" {
+  name               = "tabular-storage"
+  assume_role_policy = provider::tabular::assume_role_policy(var.organization_id)
+}
@@ -0,0 +1,4 @@
+resource "aws_iam_role_policy" "tabular_read_only" {
+  role   = aws_iam_role.tabular_read_only.id
+  policy = provider::tabular::iam_read_only_policy("my-shared-bucket")
+}
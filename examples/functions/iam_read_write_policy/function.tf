@@ -0,0 +1,4 @@
+resource "aws_iam_role_policy" "tabular" {
+  role   = aws_iam_role.tabular.id
+  policy = provider::tabular::iam_read_write_policy("my-warehouse-bucket")
+}
@@ -0,0 +1,4 @@
+resource "aws_emr_cluster" "spark" {
+  # ...
+  configurations_json = provider::tabular::iam_role_mapping_spark_config("funhouse", "us-west-2")
+}
@@ -0,0 +1,22 @@
+output "events_schema" {
+  value = provider::tabular::iceberg_schema([
+    { name = "id", type = "long", required = true, identifier = true },
+    { name = "ts", type = "timestamptz", required = true },
+    { name = "amount", type = "decimal(10, 2)", doc = "Amount in USD" },
+    { name = "tags", type = "list", element = "string" },
+    {
+      name = "location"
+      type = "struct"
+      fields = [
+        { name = "lat", type = "double" },
+        { name = "lon", type = "double" },
+      ]
+    },
+    {
+      name  = "attributes"
+      type  = "map"
+      key   = "string"
+      value = { type = "list", element = "string" }
+    },
+  ])
+}
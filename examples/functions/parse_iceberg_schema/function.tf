@@ -0,0 +1,13 @@
+data "tabular_table" "events" {
+  warehouse_id = "2f8efb1d-81f6-4b83-8fae-ec30653a89eb"
+  database     = "analytics"
+  name         = "events"
+}
+
+locals {
+  events_schema = provider::tabular::parse_iceberg_schema(data.tabular_table.events.schema_json)
+}
+
+output "required_columns" {
+  value = [for field in local.events_schema.fields : field.name if field.required]
+}
@@ -0,0 +1,14 @@
+variable "partition_by" {
+  type    = list(string)
+  default = ["day(ts)", "bucket(16, id)"]
+
+  validation {
+    condition     = alltrue([for expression in var.partition_by : can(provider::tabular::partition_transform(expression))])
+    error_message = "Each partition_by entry must be a column or a transform such as bucket(16, id) or day(ts)."
+  }
+}
+
+output "partition_fields" {
+  # [{ name = "ts_day", source_column = "ts", transform = "day" }, { name = "id_bucket", source_column = "id", transform = "bucket[16]" }]
+  value = [for expression in var.partition_by : provider::tabular::partition_transform(expression)]
+}
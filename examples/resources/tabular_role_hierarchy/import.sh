@@ -0,0 +1,2 @@
+# Role hierarchies can be imported by specifying the parent role name
+terraform import tabular_role_hierarchy.engineering engineering
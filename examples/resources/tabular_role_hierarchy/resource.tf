@@ -0,0 +1,16 @@
+resource "tabular_role" "engineering" {
+  name = "engineering"
+}
+
+resource "tabular_role" "data" {
+  name = "data"
+}
+
+resource "tabular_role" "ml" {
+  name = "ml"
+}
+
+resource "tabular_role_hierarchy" "engineering" {
+  role_name = tabular_role.engineering.name
+  children  = [tabular_role.data.name, tabular_role.ml.name]
+}
@@ -0,0 +1,2 @@
+# Role members can be imported with the role name and the member's email (or member ID), split by a /
+terraform import tabular_role_member.example_member "Example Role/user@tabular.io"
@@ -0,0 +1,19 @@
+resource "tabular_role" "example" {
+  name = "Example Role"
+}
+
+resource "tabular_role_member" "example_member" {
+  role_name = tabular_role.example.name
+  email     = "user@tabular.io"
+}
+
+resource "tabular_role_member" "example_admin" {
+  role_name  = tabular_role.example.name
+  email      = "role_admin@tabular.io"
+  with_admin = true
+}
+
+resource "tabular_role_member" "example_service_account" {
+  role_name       = tabular_role.example.name
+  service_account = "nightly-ingest"
+}
@@ -6,4 +6,34 @@ resource "tabular_role_membership" "example_members" {
   role_name     = tabular_role.example.name
   admin_members = ["role_admin@tabular.io"]
   members       = ["user@tabular.io"]
-}
\ No newline at end of file
+}
+
+# Member IDs keep working when a user changes their email address
+resource "tabular_role_membership" "platform_members" {
+  role_name        = tabular_role.example.name
+  admin_member_ids = ["00000000-0000-0000-0000-000000000001"]
+  member_ids       = ["00000000-0000-0000-0000-000000000002"]
+  mode             = "additive"
+}
+
+resource "tabular_role_membership" "pipeline_members" {
+  role_name        = tabular_role.example.name
+  service_accounts = ["nightly-ingest"]
+  mode             = "additive"
+}
+
+# Additive membership leaves members managed elsewhere untouched
+resource "tabular_role_membership" "onboarding_members" {
+  role_name     = tabular_role.example.name
+  admin_members = []
+  members       = ["new_user@tabular.io"]
+  mode          = "additive"
+}
+
+# New hires are added to the role on the first apply after they accept their org invitation
+resource "tabular_role_membership" "new_hire_members" {
+  role_name             = tabular_role.example.name
+  members               = ["new_hire@tabular.io"]
+  mode                  = "additive"
+  defer_missing_members = true
+}
@@ -0,0 +1,15 @@
+resource "tabular_role" "analysts" {
+  name = "analysts"
+}
+
+resource "tabular_role_membership_rule" "data_team" {
+  role_name        = tabular_role.analysts.name
+  email_patterns   = ["*@data.example.com"]
+  exclude_patterns = ["contractor.*@data.example.com"]
+}
+
+resource "tabular_role_membership_rule" "ml_team" {
+  role_name      = tabular_role.analysts.name
+  email_patterns = ["[a-z.]+@ml\\.example\\.com"]
+  pattern_syntax = "regex"
+}
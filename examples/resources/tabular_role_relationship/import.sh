@@ -1,2 +1,5 @@
-# Role relationships can be imported with the `ParentRoleName/ChildRoleName` format
-terraform import tabular_role_relationship.inheritance "Example Role 1/Example Role 2"
\ No newline at end of file
+# Role relationships can be imported with the `ParentRoleId/ChildRoleId` format
+terraform import tabular_role_relationship.inheritance "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002"
+
+# or with the `ParentRoleName/ChildRoleName` format
+terraform import tabular_role_relationship.inheritance "Example Role 1/Example Role 2"
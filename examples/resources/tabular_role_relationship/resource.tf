@@ -9,4 +9,12 @@ resource "tabular_role" "example2" {
 resource "tabular_role_relationship" "inheritance" {
   parent_role_name = tabular_role.example.name
   child_role_name  = tabular_role.example2.name
-}
\ No newline at end of file
+}
+
+# Pinning the role IDs guards against a different role taking over one of the names
+resource "tabular_role_relationship" "pinned" {
+  parent_role_id   = tabular_role.example.id
+  parent_role_name = tabular_role.example.name
+  child_role_id    = tabular_role.example2.id
+  child_role_name  = tabular_role.example2.name
+}
@@ -0,0 +1,3 @@
+# Maintenance settings can be imported with the `Warehouse ID/Database` format for a database,
+# or the `Warehouse ID/Database/Table` format for a table
+terraform import tabular_table_maintenance.events "2f8efb1d-81f6-4b83-8fae-ec30653a89eb/analytics/events"
\ No newline at end of file
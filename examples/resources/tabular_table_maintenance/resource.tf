@@ -0,0 +1,28 @@
+data "tabular_warehouse" "warehouse" {
+  name = "funhouse"
+}
+
+# Defaults for every table in the database
+resource "tabular_table_maintenance" "analytics" {
+  warehouse_id          = data.tabular_warehouse.warehouse.id
+  database              = "analytics"
+  max_snapshot_age_ms   = 7 * 24 * 60 * 60 * 1000
+  min_snapshots_to_keep = 10
+  compaction_enabled    = true
+}
+
+# Keeps a month of history for one table, inheriting the rest from the database
+resource "tabular_table_maintenance" "events" {
+  warehouse_id                         = data.tabular_warehouse.warehouse.id
+  database                             = "analytics"
+  table                                = "events"
+  max_snapshot_age_ms                  = 30 * 24 * 60 * 60 * 1000
+  target_file_size_bytes               = 256 * 1024 * 1024
+  orphan_file_cleanup_enabled          = true
+  metadata_previous_versions_max       = 50
+  metadata_delete_after_commit_enabled = true
+}
+
+output "events_compaction_enabled" {
+  value = tabular_table_maintenance.events.effective_settings.compaction_enabled
+}
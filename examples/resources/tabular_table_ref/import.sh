@@ -0,0 +1,2 @@
+# Branches and tags can be imported with the `Warehouse ID/Database/Table/Name` format
+terraform import tabular_table_ref.audit "2f8efb1d-81f6-4b83-8fae-ec30653a89eb/analytics/events/audit"
\ No newline at end of file
@@ -0,0 +1,25 @@
+data "tabular_warehouse" "warehouse" {
+  name = "funhouse"
+}
+
+# Write-audit-publish: jobs write to the audit branch, which is published once checks pass
+resource "tabular_table_ref" "audit" {
+  warehouse_id          = data.tabular_warehouse.warehouse.id
+  database              = "analytics"
+  table                 = "events"
+  name                  = "audit"
+  type                  = "branch"
+  max_snapshot_age_ms   = 3 * 24 * 60 * 60 * 1000
+  min_snapshots_to_keep = 5
+}
+
+# Keeps the end of quarter snapshot around for a year
+resource "tabular_table_ref" "q4_close" {
+  warehouse_id   = data.tabular_warehouse.warehouse.id
+  database       = "analytics"
+  table          = "events"
+  name           = "2026-q4-close"
+  type           = "tag"
+  snapshot_id    = 8744736658442914487
+  max_ref_age_ms = 365 * 24 * 60 * 60 * 1000
+}
@@ -0,0 +1,2 @@
+# Views can be imported with the `Warehouse ID/Database/View` format
+terraform import tabular_view.daily_events "2f8efb1d-81f6-4b83-8fae-ec30653a89eb/analytics/daily_events"
\ No newline at end of file
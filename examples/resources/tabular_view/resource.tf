@@ -0,0 +1,23 @@
+data "tabular_warehouse" "warehouse" {
+  name = "funhouse"
+}
+
+resource "tabular_view" "daily_events" {
+  warehouse_id = data.tabular_warehouse.warehouse.id
+  database     = "analytics"
+  name         = "daily_events"
+
+  sql = {
+    spark = "SELECT event_date, count(*) AS events FROM events GROUP BY event_date"
+    trino = "SELECT event_date, count(*) AS events FROM events GROUP BY event_date"
+  }
+
+  columns = [
+    { name = "event_date", type = "date" },
+    { name = "events", type = "long", required = true, doc = "Events on the day" },
+  ]
+
+  properties = {
+    comment = "Events per day"
+  }
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &AssumeRolePolicyFunction{}
+
+func NewAssumeRolePolicyFunction() function.Function {
+	return &AssumeRolePolicyFunction{}
+}
+
+type AssumeRolePolicyFunction struct{}
+
+func (f *AssumeRolePolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "assume_role_policy"
+}
+
+func (f *AssumeRolePolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Renders the trust policy letting Tabular assume a role",
+		MarkdownDescription: "Renders the trust policy letting Tabular assume an IAM role. The external ID is the Tabular organization ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "external_id",
+				MarkdownDescription: "The Tabular organization ID",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *AssumeRolePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var externalId string
+	resp.Error = req.Arguments.Get(ctx, &externalId)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, AssumeRolePolicy(externalId))
+}
@@ -38,7 +38,8 @@ func (d *AWSIAMPolicyDataSource) Metadata(ctx context.Context, req datasource.Me
 func (d *AWSIAMPolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Tabular AWS IAMPolicy data source",
+		MarkdownDescription: "Tabular AWS IAMPolicy data source. On Terraform 1.8 and later the `iam_read_write_policy`, " +
+			"`iam_read_only_policy` and `assume_role_policy` provider functions render the same policies.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,6 +25,26 @@ func TestAWSIAMPolicyValidJSON(t *testing.T) {
 	assert.True(t, json.Valid([]byte(AssumeRolePolicy("some-external-id-1234"))))
 }
 
+func TestAWSIAMPolicyFunctions(t *testing.T) {
+	assert.Equal(t, IAMReadWritePolicy("my-bucket-name"), runStringFunction(t, &IAMReadWritePolicyFunction{}, "my-bucket-name"))
+	assert.Equal(t, IAMReadOnlyPolicy("my-bucket-name"), runStringFunction(t, &IAMReadOnlyPolicyFunction{}, "my-bucket-name"))
+	assert.Equal(t, AssumeRolePolicy("some-external-id-1234"), runStringFunction(t, &AssumeRolePolicyFunction{}, "some-external-id-1234"))
+}
+
+// runStringFunction runs a function taking and returning strings, failing the test if it errors
+func runStringFunction(t *testing.T, f function.Function, args ...string) string {
+	values := make([]attr.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, types.StringValue(arg))
+	}
+	req := function.RunRequest{Arguments: function.NewArgumentsData(values)}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(context.Background(), req, &resp)
+
+	assert.Nil(t, resp.Error)
+	return resp.Result.Value().(types.String).ValueString()
+}
+
 func TestAWSIAMPolicyDataSource(t *testing.T) {
 	externalId := os.Getenv("TABULAR_ORGANIZATION_ID")
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	engineSpark     = "spark"
+	engineTrino     = "trino"
+	engineFlink     = "flink"
+	enginePyIceberg = "pyiceberg"
+	engineDuckDB    = "duckdb"
+)
+
+var computeEngines = []string{engineSpark, engineTrino, engineFlink, enginePyIceberg, engineDuckDB}
+
+const (
+	// authModeSigV4 signs requests to the IAM gateway with the caller's AWS credentials
+	authModeSigV4 = "sigv4"
+	// authModeServiceAccount exchanges a service account credential for tokens
+	authModeServiceAccount = "service_account"
+	// authModeOAuthToken sends an existing OAuth token
+	authModeOAuthToken = "oauth_token"
+)
+
+var computeAuthModes = []string{authModeSigV4, authModeServiceAccount, authModeOAuthToken}
+
+// catalogProperty is a single configuration key and value. Engines are configured with ordered lists of them so the
+// rendered configuration is stable.
+type catalogProperty struct {
+	Key   string
+	Value string
+}
+
+// catalogConnection describes how an engine reaches a warehouse through Tabular's Iceberg REST catalog
+type catalogConnection struct {
+	CatalogName string
+	Warehouse   string
+	Uri         string
+	Region      string
+	AuthMode    string
+	// Credential is a service account's key:secret, empty when it's supplied outside the rendered config
+	Credential string
+	// Token is an OAuth token, empty when it's supplied outside the rendered config
+	Token string
+}
+
+// iamGatewayConnection connects through the IAM gateway, which signs requests with SigV4 and maps the caller's IAM
+// role to a Tabular role
+func iamGatewayConnection(warehouseName, region string) catalogConnection {
+	return catalogConnection{
+		CatalogName: warehouseName,
+		Warehouse:   warehouseName,
+		Uri:         iamGatewayUri(region),
+		Region:      region,
+		AuthMode:    authModeSigV4,
+	}
+}
+
+func iamGatewayUri(region string) string {
+	return fmt.Sprintf("https://iam-gw.%s.tabular.io/ws/", region)
+}
+
+// catalogUri is the REST catalog endpoint for an auth mode; OAuth clients talk to the provider's Tabular API endpoint
+// directly
+func catalogUri(authMode, endpoint, region string) string {
+	if authMode == authModeServiceAccount || authMode == authModeOAuthToken {
+		return endpoint + "/ws"
+	}
+	return iamGatewayUri(region)
+}
+
+func (c catalogConnection) usesOAuth() bool {
+	return c.AuthMode == authModeServiceAccount || c.AuthMode == authModeOAuthToken
+}
+
+func (c catalogConnection) hasSecret() bool {
+	return c.Credential != "" || c.Token != ""
+}
+
+// withoutSecret drops the credential and token, leaving the engine to be given them separately
+func (c catalogConnection) withoutSecret() catalogConnection {
+	c.Credential = ""
+	c.Token = ""
+	return c
+}
+
+// oauth2ServerUri is the catalog's token endpoint, which Iceberg clients otherwise derive from the uri with a warning
+func (c catalogConnection) oauth2ServerUri() string {
+	return strings.TrimSuffix(c.Uri, "/") + "/v1/oauth/tokens"
+}
+
+// icebergProperties are the Iceberg REST catalog properties Spark, Flink and PyIceberg all understand
+func (c catalogConnection) icebergProperties() []catalogProperty {
+	properties := []catalogProperty{
+		{"uri", c.Uri},
+		{"warehouse", c.Warehouse},
+	}
+	switch c.AuthMode {
+	case authModeServiceAccount:
+		if c.Credential != "" {
+			properties = append(properties, catalogProperty{"credential", c.Credential})
+		}
+		return append(properties,
+			catalogProperty{"oauth2-server-uri", c.oauth2ServerUri()},
+			catalogProperty{"token-exchange-enabled", "true"},
+		)
+	case authModeOAuthToken:
+		if c.Token != "" {
+			properties = append(properties, catalogProperty{"token", c.Token})
+		}
+		return append(properties, catalogProperty{"oauth2-server-uri", c.oauth2ServerUri()})
+	default:
+		return append(properties, catalogProperty{"rest.sigv4-enabled", "true"})
+	}
+}
+
+func (c catalogConnection) sparkProperties() []catalogProperty {
+	prefix := "spark.sql.catalog." + c.CatalogName
+	properties := []catalogProperty{
+		{prefix, "org.apache.iceberg.spark.SparkCatalog"},
+		{prefix + ".catalog-impl", "org.apache.iceberg.rest.RESTCatalog"},
+	}
+	for _, property := range c.icebergProperties() {
+		properties = append(properties, catalogProperty{prefix + "." + property.Key, property.Value})
+	}
+	return append(properties,
+		catalogProperty{"spark.sql.defaultCatalog", c.CatalogName},
+		catalogProperty{"spark.sql.extensions", "org.apache.iceberg.spark.extensions.IcebergSparkSessionExtensions"},
+	)
+}
+
+type emrClassification struct {
+	Classification string            `json:"Classification"`
+	Properties     map[string]string `json:"Properties"`
+}
+
+// renderEMRConfig renders EMR configuration classifications, as taken by a cluster's configurations_json. The plain
+// IAM gateway connection keeps the original hand-written layout so existing configs don't change.
+func (c catalogConnection) renderEMRConfig() string {
+	if c == iamGatewayConnection(c.Warehouse, c.Region) {
+		return fmt.Sprintf(`[
+	  {
+		"Classification": "iceberg-defaults",
+		"Properties": {
+		  "iceberg.enabled": "true"
+		}
+	  },
+	  {
+		"Classification": "spark-defaults",
+		"Properties": {
+		  "spark.sql.catalog.%[1]s": "org.apache.iceberg.spark.SparkCatalog",
+		  "spark.sql.catalog.%[1]s.catalog-impl": "org.apache.iceberg.rest.RESTCatalog",
+		  "spark.sql.catalog.%[1]s.rest.sigv4-enabled": "true",
+		  "spark.sql.catalog.%[1]s.uri": "https://iam-gw.%[2]s.tabular.io/ws/",
+		  "spark.sql.catalog.%[1]s.warehouse": "%[1]s",
+		  "spark.sql.defaultCatalog": "%[1]s",
+		  "spark.sql.extensions": "org.apache.iceberg.spark.extensions.IcebergSparkSessionExtensions"
+		}
+	  }
+	]`, c.Warehouse, c.Region)
+	}
+	sparkDefaults := make(map[string]string)
+	for _, property := range c.sparkProperties() {
+		sparkDefaults[property.Key] = property.Value
+	}
+	config, _ := json.MarshalIndent([]emrClassification{
+		{Classification: "iceberg-defaults", Properties: map[string]string{"iceberg.enabled": "true"}},
+		{Classification: "spark-defaults", Properties: sparkDefaults},
+	}, "", "  ")
+	return string(config)
+}
+
+func (c catalogConnection) renderSparkDefaults() string {
+	var b strings.Builder
+	for _, property := range c.sparkProperties() {
+		fmt.Fprintf(&b, "%s %s\n", property.Key, property.Value)
+	}
+	return b.String()
+}
+
+// sparkConfArgs returns spark-submit and spark-shell arguments, a --conf for each property
+func (c catalogConnection) sparkConfArgs() []string {
+	args := make([]string, 0)
+	for _, property := range c.sparkProperties() {
+		args = append(args, "--conf", property.Key+"="+property.Value)
+	}
+	return args
+}
+
+// renderTrinoCatalog renders a catalog properties file, e.g. etc/catalog/<warehouse>.properties
+func (c catalogConnection) renderTrinoCatalog() string {
+	properties := []catalogProperty{
+		{"connector.name", "iceberg"},
+		{"iceberg.catalog.type", "rest"},
+		{"iceberg.rest-catalog.uri", c.Uri},
+		{"iceberg.rest-catalog.warehouse", c.Warehouse},
+	}
+	if c.usesOAuth() {
+		properties = append(properties, catalogProperty{"iceberg.rest-catalog.security", "OAUTH2"})
+		if c.Credential != "" {
+			properties = append(properties, catalogProperty{"iceberg.rest-catalog.oauth2.credential", c.Credential})
+		}
+		if c.Token != "" {
+			properties = append(properties, catalogProperty{"iceberg.rest-catalog.oauth2.token", c.Token})
+		}
+		properties = append(properties, catalogProperty{"iceberg.rest-catalog.oauth2.server-uri", c.oauth2ServerUri()})
+	} else {
+		properties = append(properties, catalogProperty{"iceberg.rest-catalog.security", "SIGV4"})
+	}
+	properties = append(properties,
+		catalogProperty{"iceberg.rest-catalog.vended-credentials-enabled", "true"},
+		catalogProperty{"fs.native-s3.enabled", "true"},
+		catalogProperty{"s3.region", c.Region},
+	)
+	var b strings.Builder
+	for _, property := range properties {
+		fmt.Fprintf(&b, "%s=%s\n", property.Key, property.Value)
+	}
+	return b.String()
+}
+
+func (c catalogConnection) renderFlinkSQL() string {
+	properties := append([]catalogProperty{{"type", "iceberg"}, {"catalog-type", "rest"}}, c.icebergProperties()...)
+	options := make([]string, 0, len(properties))
+	for _, property := range properties {
+		options = append(options, fmt.Sprintf("  %s = %s", sqlString(property.Key), sqlString(property.Value)))
+	}
+	return fmt.Sprintf("CREATE CATALOG %s WITH (\n%s\n);\n", sqlIdentifier(c.CatalogName, "`"), strings.Join(options, ",\n"))
+}
+
+// renderPyIcebergYaml renders a .pyiceberg.yaml. Values are written as JSON strings, which YAML reads unchanged.
+func (c catalogConnection) renderPyIcebergYaml() string {
+	properties := append([]catalogProperty{{"type", "rest"}}, c.icebergProperties()...)
+	if c.AuthMode == authModeSigV4 {
+		properties = append(properties, catalogProperty{"rest.signing-region", c.Region})
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "catalog:\n  %s:\n", yamlString(c.CatalogName))
+	for _, property := range properties {
+		fmt.Fprintf(&b, "    %s: %s\n", property.Key, yamlString(property.Value))
+	}
+	return b.String()
+}
+
+// renderDuckDBSQL attaches the warehouse with DuckDB's iceberg extension. SigV4 signs with credentials from the AWS
+// credential chain, OAuth modes authenticate with an iceberg secret.
+func (c catalogConnection) renderDuckDBSQL() string {
+	var b strings.Builder
+	b.WriteString("INSTALL iceberg;\nLOAD iceberg;\n")
+	authorizationType := "sigv4"
+	if c.usesOAuth() {
+		authorizationType = "oauth2"
+		var options []string
+		if key, secret, ok := strings.Cut(c.Credential, ":"); ok {
+			options = append(options, "CLIENT_ID "+sqlString(key), "CLIENT_SECRET "+sqlString(secret))
+		}
+		if c.Token != "" {
+			options = append(options, "TOKEN "+sqlString(c.Token))
+		}
+		if len(options) > 0 {
+			options = append(options, "OAUTH2_SERVER_URI "+sqlString(c.oauth2ServerUri()))
+			fmt.Fprintf(&b, "CREATE SECRET (TYPE iceberg, %s);\n", strings.Join(options, ", "))
+		}
+	} else {
+		fmt.Fprintf(&b, "CREATE SECRET (TYPE s3, PROVIDER credential_chain, REGION %s);\n", sqlString(c.Region))
+	}
+	fmt.Fprintf(&b, "ATTACH %s AS %s (\n  TYPE iceberg,\n  ENDPOINT %s,\n  AUTHORIZATION_TYPE %s\n);\n",
+		sqlString(c.Warehouse), sqlIdentifier(c.CatalogName, `"`), sqlString(strings.TrimSuffix(c.Uri, "/")), sqlString(authorizationType))
+	return b.String()
+}
+
+func sqlString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// sqlIdentifier quotes an identifier with the quote character the engine uses, backticks for Flink and double quotes
+// for DuckDB
+func sqlIdentifier(name, quote string) string {
+	return quote + strings.ReplaceAll(name, quote, quote+quote) + quote
+}
+
+func yamlString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
@@ -3,15 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &ComputeConfigDataSource{}
 var _ datasource.DataSourceWithConfigure = &ComputeConfigDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ComputeConfigDataSource{}
 
 func NewComputeConfigDataSource() datasource.DataSource {
 	return &ComputeConfigDataSource{}
@@ -24,10 +30,46 @@ type ComputeConfigDataSource struct {
 
 // ComputeConfigDataSourceModel describes the data source data model.
 type ComputeConfigDataSourceModel struct {
-	Id            types.String `tfsdk:"id"`
-	WareHouseId   types.String `tfsdk:"warehouse_id"`
-	WarehouseName types.String `tfsdk:"warehouse_name"`
+	Id               types.String `tfsdk:"id"`
+	WareHouseId      types.String `tfsdk:"warehouse_id"`
+	WarehouseName    types.String `tfsdk:"warehouse_name"`
+	IgnoreCase       types.Bool   `tfsdk:"ignore_case"`
+	Engine           types.String `tfsdk:"engine"`
+	AuthMode         types.String `tfsdk:"auth_mode"`
+	CredentialKey    types.String `tfsdk:"credential_key"`
+	CredentialSecret types.String `tfsdk:"credential_secret"`
+	CatalogName      types.String `tfsdk:"catalog_name"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+	SparkConfig      types.String `tfsdk:"spark_config"`
+	SparkDefaults    types.String `tfsdk:"spark_defaults"`
+	SparkConfArgs    types.List   `tfsdk:"spark_conf_args"`
+	TrinoCatalog     types.String `tfsdk:"trino_catalog_properties"`
+	FlinkSQL         types.String `tfsdk:"flink_sql"`
+	PyIceberg        types.String `tfsdk:"pyiceberg_yaml"`
+	DuckDBSQL        types.String `tfsdk:"duckdb_sql"`
+	WithCredential   types.Object `tfsdk:"with_credential"`
+}
+
+// computeEngineConfigModel is the rendered configuration including the credential, kept apart from the top-level
+// attributes so only it has to be sensitive
+type computeEngineConfigModel struct {
 	SparkConfig   types.String `tfsdk:"spark_config"`
+	SparkDefaults types.String `tfsdk:"spark_defaults"`
+	SparkConfArgs types.List   `tfsdk:"spark_conf_args"`
+	TrinoCatalog  types.String `tfsdk:"trino_catalog_properties"`
+	FlinkSQL      types.String `tfsdk:"flink_sql"`
+	PyIceberg     types.String `tfsdk:"pyiceberg_yaml"`
+	DuckDBSQL     types.String `tfsdk:"duckdb_sql"`
+}
+
+var computeEngineConfigAttrTypes = map[string]attr.Type{
+	"spark_config":             types.StringType,
+	"spark_defaults":           types.StringType,
+	"spark_conf_args":          types.ListType{ElemType: types.StringType},
+	"trino_catalog_properties": types.StringType,
+	"flink_sql":                types.StringType,
+	"pyiceberg_yaml":           types.StringType,
+	"duckdb_sql":               types.StringType,
 }
 
 func (d *ComputeConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -37,7 +79,8 @@ func (d *ComputeConfigDataSource) Metadata(ctx context.Context, req datasource.M
 func (d *ComputeConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Tabular ComputeConfig data source",
+		MarkdownDescription: "Tabular ComputeConfig data source. On Terraform 1.8 and later the " +
+			"`iam_role_mapping_spark_config` provider function renders the same config from a warehouse name and region.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -45,17 +88,105 @@ func (d *ComputeConfigDataSource) Schema(ctx context.Context, req datasource.Sch
 				Computed:    true,
 			},
 			"warehouse_id": schema.StringAttribute{
-				MarkdownDescription: "Warehouse ID",
+				MarkdownDescription: "Warehouse ID. Exactly one of `warehouse_id` or `warehouse_name` must be set.",
 				Optional:            true,
+				Computed:            true,
 			},
 			"warehouse_name": schema.StringAttribute{
-				MarkdownDescription: "Warehouse Name",
+				MarkdownDescription: "Warehouse Name. Exactly one of `warehouse_id` or `warehouse_name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"ignore_case": schema.BoolAttribute{
+				MarkdownDescription: "Match `warehouse_name` case-insensitively when no warehouse has exactly that name",
 				Optional:            true,
 			},
+			"engine": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Engine to render catalog configuration for, one of %s. Defaults to `spark`. "+
+					"Only the selected engine's attributes are set.", computeEngines),
+				Optional: true,
+				Validators: []validator.String{
+					validators.StringOneOfValidator{Values: computeEngines},
+				},
+			},
+			"auth_mode": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How the engine authenticates to the catalog, one of %s. Defaults to `sigv4`, "+
+					"which goes through the IAM gateway and requires an IAM role mapping. `service_account` exchanges a "+
+					"service account credential for tokens and `oauth_token` sends an existing token.", computeAuthModes),
+				Optional: true,
+				Validators: []validator.String{
+					validators.StringOneOfValidator{Values: computeAuthModes},
+				},
+			},
+			"credential_key": schema.StringAttribute{
+				MarkdownDescription: "Service account credential key to render into `with_credential`, " +
+					"e.g. from `tabular_service_account`. Only valid with `auth_mode = \"service_account\"`, " +
+					"and requires `credential_secret`.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"credential_secret": schema.StringAttribute{
+				MarkdownDescription: "Service account credential secret with `auth_mode = \"service_account\"`, or the " +
+					"token with `auth_mode = \"oauth_token\"`. It's only rendered into `with_credential`; the top-level " +
+					"configuration attributes leave it out for the engine to be given separately.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"catalog_name": schema.StringAttribute{
+				MarkdownDescription: "Name engines register the catalog under. Defaults to the warehouse name.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Iceberg REST catalog endpoint. Defaults to the region's IAM gateway with " +
+					"`sigv4` and to the provider's endpoint followed by `/ws` otherwise.",
+				Optional: true,
+				Computed: true,
+			},
 			"spark_config": schema.StringAttribute{
-				MarkdownDescription: "Spark Config that can be used to configure compute",
+				MarkdownDescription: "Spark Config that can be used to configure compute, as EMR configuration classifications JSON",
 				Computed:            true,
 			},
+			"spark_defaults": schema.StringAttribute{
+				MarkdownDescription: "Spark config in `spark-defaults.conf` format",
+				Computed:            true,
+			},
+			"spark_conf_args": schema.ListAttribute{
+				MarkdownDescription: "Spark config as `spark-submit` arguments, a `--conf` for each property",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"trino_catalog_properties": schema.StringAttribute{
+				MarkdownDescription: "Trino Iceberg connector catalog properties file",
+				Computed:            true,
+			},
+			"flink_sql": schema.StringAttribute{
+				MarkdownDescription: "Flink SQL `CREATE CATALOG` statement",
+				Computed:            true,
+			},
+			"pyiceberg_yaml": schema.StringAttribute{
+				MarkdownDescription: "PyIceberg `.pyiceberg.yaml` catalog configuration",
+				Computed:            true,
+			},
+			"duckdb_sql": schema.StringAttribute{
+				MarkdownDescription: "DuckDB statements attaching the warehouse with the iceberg extension",
+				Computed:            true,
+			},
+			"with_credential": schema.SingleNestedAttribute{
+				MarkdownDescription: "The selected engine's configuration including `credential_key` and `credential_secret`, " +
+					"set when they are. Sensitive, unlike the top-level attributes.",
+				Computed:  true,
+				Sensitive: true,
+				Attributes: map[string]schema.Attribute{
+					"spark_config":             schema.StringAttribute{Computed: true},
+					"spark_defaults":           schema.StringAttribute{Computed: true},
+					"spark_conf_args":          schema.ListAttribute{Computed: true, ElementType: types.StringType},
+					"trino_catalog_properties": schema.StringAttribute{Computed: true},
+					"flink_sql":                schema.StringAttribute{Computed: true},
+					"pyiceberg_yaml":           schema.StringAttribute{Computed: true},
+					"duckdb_sql":               schema.StringAttribute{Computed: true},
+				},
+			},
 		},
 	}
 }
@@ -80,6 +211,32 @@ func (d *ComputeConfigDataSource) Configure(ctx context.Context, req datasource.
 	d.client = client
 }
 
+func (d *ComputeConfigDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data ComputeConfigDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch data.AuthMode.ValueString() {
+	case authModeServiceAccount:
+		if data.CredentialKey.IsNull() != data.CredentialSecret.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("credential_secret"), "Incomplete credential",
+				"credential_key and credential_secret must be set together")
+		}
+	case authModeOAuthToken:
+		if !data.CredentialKey.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("credential_key"), "Invalid credential",
+				"credential_key can't be used with oauth_token, set the token as credential_secret")
+		}
+	default:
+		if !data.CredentialKey.IsNull() || !data.CredentialSecret.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("auth_mode"), "Invalid credential",
+				"credential_key and credential_secret require auth_mode service_account or oauth_token")
+		}
+	}
+}
+
 func (d *ComputeConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Load ComputeConfigData from config
 	var computeConfigData ComputeConfigDataSourceModel
@@ -92,38 +249,106 @@ func (d *ComputeConfigDataSource) Read(ctx context.Context, req datasource.ReadR
 	var warehouseData WarehouseDataSourceModel
 	warehouseData.Id = computeConfigData.WareHouseId
 	warehouseData.Name = computeConfigData.WarehouseName
-	GetWarehouseByIdOrName(ctx, *d.client, &warehouseData, resp)
+	GetWarehouseByIdOrName(ctx, *d.client, &warehouseData, computeConfigData.IgnoreCase.ValueBool(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set spark config
 	computeConfigData.Id = warehouseData.Id
 	computeConfigData.WareHouseId = warehouseData.Id
-	computeConfigData.WarehouseName = warehouseData.Name
-	sparkConfig := GetIAMRoleMappingSparkConfig(warehouseData.Name.ValueString(), warehouseData.Region.ValueString())
-	computeConfigData.SparkConfig = types.StringValue(sparkConfig)
+	// A case-insensitive match has to report the name as configured, the catalog still uses the real name
+	if computeConfigData.WarehouseName.IsNull() {
+		computeConfigData.WarehouseName = warehouseData.Name
+	}
+	connection := newCatalogConnection(&computeConfigData, d.client.V1.Endpoint, warehouseData.Name.ValueString(), warehouseData.Region.ValueString())
+	computeConfigData.CatalogName = types.StringValue(connection.CatalogName)
+	computeConfigData.Endpoint = types.StringValue(connection.Uri)
+	resp.Diagnostics.Append(setEngineConfig(ctx, &computeConfigData, connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Add ComputeConfigData to response
 	resp.Diagnostics.Append(resp.State.Set(ctx, &computeConfigData)...)
 }
 
-func GetIAMRoleMappingSparkConfig(warehouseName string, warehouseRegion string) string {
-	return fmt.Sprintf(`[
-	  {
-		"Classification": "iceberg-defaults",
-		"Properties": {
-		  "iceberg.enabled": "true"
-		}
-	  },
-	  {
-		"Classification": "spark-defaults",
-		"Properties": {
-		  "spark.sql.catalog.%[1]s": "org.apache.iceberg.spark.SparkCatalog",
-		  "spark.sql.catalog.%[1]s.catalog-impl": "org.apache.iceberg.rest.RESTCatalog",
-		  "spark.sql.catalog.%[1]s.rest.sigv4-enabled": "true",
-		  "spark.sql.catalog.%[1]s.uri": "https://iam-gw.%[2]s.tabular.io/ws/",
-		  "spark.sql.catalog.%[1]s.warehouse": "%[1]s",
-		  "spark.sql.defaultCatalog": "%[1]s",
-		  "spark.sql.extensions": "org.apache.iceberg.spark.extensions.IcebergSparkSessionExtensions"
+// newCatalogConnection applies the configured auth mode and overrides to the warehouse's connection. OAuth modes
+// default to the provider's endpoint.
+func newCatalogConnection(data *ComputeConfigDataSourceModel, endpoint, warehouseName, region string) catalogConnection {
+	connection := iamGatewayConnection(warehouseName, region)
+	if !data.AuthMode.IsNull() {
+		connection.AuthMode = data.AuthMode.ValueString()
+		connection.Uri = catalogUri(connection.AuthMode, endpoint, region)
+	}
+	if !data.CatalogName.IsNull() && !data.CatalogName.IsUnknown() {
+		connection.CatalogName = data.CatalogName.ValueString()
+	}
+	if !data.Endpoint.IsNull() && !data.Endpoint.IsUnknown() {
+		connection.Uri = data.Endpoint.ValueString()
+	}
+	switch connection.AuthMode {
+	case authModeServiceAccount:
+		if !data.CredentialKey.IsNull() {
+			connection.Credential = data.CredentialKey.ValueString() + ":" + data.CredentialSecret.ValueString()
 		}
-	  }
-	]`, warehouseName, warehouseRegion)
+	case authModeOAuthToken:
+		connection.Token = data.CredentialSecret.ValueString()
+	}
+	return connection
+}
+
+// setEngineConfig renders the selected engine's configuration, leaving every other engine's attributes null. The
+// top-level attributes never contain the credential, with_credential does when one is configured.
+func setEngineConfig(ctx context.Context, data *ComputeConfigDataSourceModel, connection catalogConnection) diag.Diagnostics {
+	config, diags := renderEngineConfig(ctx, data.Engine.ValueString(), connection.withoutSecret())
+	data.SparkConfig = config.SparkConfig
+	data.SparkDefaults = config.SparkDefaults
+	data.SparkConfArgs = config.SparkConfArgs
+	data.TrinoCatalog = config.TrinoCatalog
+	data.FlinkSQL = config.FlinkSQL
+	data.PyIceberg = config.PyIceberg
+	data.DuckDBSQL = config.DuckDBSQL
+
+	data.WithCredential = types.ObjectNull(computeEngineConfigAttrTypes)
+	if connection.hasSecret() {
+		withCredential, d := renderEngineConfig(ctx, data.Engine.ValueString(), connection)
+		diags.Append(d...)
+		data.WithCredential, d = types.ObjectValueFrom(ctx, computeEngineConfigAttrTypes, withCredential)
+		diags.Append(d...)
+	}
+	return diags
+}
+
+func renderEngineConfig(ctx context.Context, engine string, connection catalogConnection) (computeEngineConfigModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := computeEngineConfigModel{
+		SparkConfig:   types.StringNull(),
+		SparkDefaults: types.StringNull(),
+		SparkConfArgs: types.ListNull(types.StringType),
+		TrinoCatalog:  types.StringNull(),
+		FlinkSQL:      types.StringNull(),
+		PyIceberg:     types.StringNull(),
+		DuckDBSQL:     types.StringNull(),
+	}
+
+	switch engine {
+	case engineSpark, "":
+		config.SparkConfig = types.StringValue(connection.renderEMRConfig())
+		config.SparkDefaults = types.StringValue(connection.renderSparkDefaults())
+		config.SparkConfArgs, diags = types.ListValueFrom(ctx, types.StringType, connection.sparkConfArgs())
+	case engineTrino:
+		config.TrinoCatalog = types.StringValue(connection.renderTrinoCatalog())
+	case engineFlink:
+		config.FlinkSQL = types.StringValue(connection.renderFlinkSQL())
+	case enginePyIceberg:
+		config.PyIceberg = types.StringValue(connection.renderPyIcebergYaml())
+	case engineDuckDB:
+		config.DuckDBSQL = types.StringValue(connection.renderDuckDBSQL())
+	}
+	return config, diags
+}
+
+func GetIAMRoleMappingSparkConfig(warehouseName string, warehouseRegion string) string {
+	return iamGatewayConnection(warehouseName, warehouseRegion).renderEMRConfig()
 }
@@ -1,12 +1,15 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +35,181 @@ func testAccComputeConfigDataSourceConfig(bucketName, roleArn, name string) stri
 
 func TestSparkConfigValidJSON(t *testing.T) {
 	assert.True(t, json.Valid([]byte(GetIAMRoleMappingSparkConfig("my-bucket-name", "us-west-2"))))
+
+	// Other catalog names and auth modes are marshalled rather than rendered from the original layout
+	connection := iamGatewayConnection("my-bucket-name", "us-west-2")
+	connection.CatalogName = "tabular"
+	assert.True(t, json.Valid([]byte(connection.renderEMRConfig())))
+	assert.Contains(t, connection.renderEMRConfig(), `"spark.sql.catalog.tabular.warehouse": "my-bucket-name"`)
+}
+
+func TestSparkConfigLayout(t *testing.T) {
+	// The default spark_config is unchanged from earlier releases
+	assert.Equal(t, `[
+	  {
+		"Classification": "iceberg-defaults",
+		"Properties": {
+		  "iceberg.enabled": "true"
+		}
+	  },
+	  {
+		"Classification": "spark-defaults",
+		"Properties": {
+		  "spark.sql.catalog.funhouse": "org.apache.iceberg.spark.SparkCatalog",
+		  "spark.sql.catalog.funhouse.catalog-impl": "org.apache.iceberg.rest.RESTCatalog",
+		  "spark.sql.catalog.funhouse.rest.sigv4-enabled": "true",
+		  "spark.sql.catalog.funhouse.uri": "https://iam-gw.us-west-2.tabular.io/ws/",
+		  "spark.sql.catalog.funhouse.warehouse": "funhouse",
+		  "spark.sql.defaultCatalog": "funhouse",
+		  "spark.sql.extensions": "org.apache.iceberg.spark.extensions.IcebergSparkSessionExtensions"
+		}
+	  }
+	]`, GetIAMRoleMappingSparkConfig("funhouse", "us-west-2"))
+}
+
+func TestIAMRoleMappingSparkConfigFunction(t *testing.T) {
+	assert.Equal(t, GetIAMRoleMappingSparkConfig("funhouse", "us-west-2"),
+		runStringFunction(t, &IAMRoleMappingSparkConfigFunction{}, "funhouse", "us-west-2"))
+}
+
+func TestSetEngineConfig(t *testing.T) {
+	connection := iamGatewayConnection("funhouse", "us-west-2")
+
+	data := ComputeConfigDataSourceModel{Engine: types.StringNull()}
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.Equal(t, GetIAMRoleMappingSparkConfig("funhouse", "us-west-2"), data.SparkConfig.ValueString())
+	assert.Contains(t, data.SparkDefaults.ValueString(), "spark.sql.catalog.funhouse.uri https://iam-gw.us-west-2.tabular.io/ws/\n")
+	assert.Equal(t, types.StringValue("--conf"), data.SparkConfArgs.Elements()[0])
+	assert.Equal(t, types.StringValue("spark.sql.catalog.funhouse=org.apache.iceberg.spark.SparkCatalog"), data.SparkConfArgs.Elements()[1])
+	assert.True(t, data.TrinoCatalog.IsNull())
+
+	data.Engine = types.StringValue(engineTrino)
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.True(t, data.SparkConfig.IsNull())
+	assert.True(t, data.SparkConfArgs.IsNull())
+	assert.Equal(t, `connector.name=iceberg
+iceberg.catalog.type=rest
+iceberg.rest-catalog.uri=https://iam-gw.us-west-2.tabular.io/ws/
+iceberg.rest-catalog.warehouse=funhouse
+iceberg.rest-catalog.security=SIGV4
+iceberg.rest-catalog.vended-credentials-enabled=true
+fs.native-s3.enabled=true
+s3.region=us-west-2
+`, data.TrinoCatalog.ValueString())
+
+	data.Engine = types.StringValue(engineFlink)
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.Equal(t, `CREATE CATALOG `+"`funhouse`"+` WITH (
+  'type' = 'iceberg',
+  'catalog-type' = 'rest',
+  'uri' = 'https://iam-gw.us-west-2.tabular.io/ws/',
+  'warehouse' = 'funhouse',
+  'rest.sigv4-enabled' = 'true'
+);
+`, data.FlinkSQL.ValueString())
+
+	data.Engine = types.StringValue(enginePyIceberg)
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.Equal(t, `catalog:
+  "funhouse":
+    type: "rest"
+    uri: "https://iam-gw.us-west-2.tabular.io/ws/"
+    warehouse: "funhouse"
+    rest.sigv4-enabled: "true"
+    rest.signing-region: "us-west-2"
+`, data.PyIceberg.ValueString())
+
+	data.Engine = types.StringValue(engineDuckDB)
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.Equal(t, `INSTALL iceberg;
+LOAD iceberg;
+CREATE SECRET (TYPE s3, PROVIDER credential_chain, REGION 'us-west-2');
+ATTACH 'funhouse' AS "funhouse" (
+  TYPE iceberg,
+  ENDPOINT 'https://iam-gw.us-west-2.tabular.io/ws',
+  AUTHORIZATION_TYPE 'sigv4'
+);
+`, data.DuckDBSQL.ValueString())
+	assert.True(t, data.PyIceberg.IsNull())
+	assert.True(t, data.FlinkSQL.IsNull())
+}
+
+func TestServiceAccountEngineConfig(t *testing.T) {
+	data := ComputeConfigDataSourceModel{
+		AuthMode:         types.StringValue(authModeServiceAccount),
+		CredentialKey:    types.StringValue("t-key"),
+		CredentialSecret: types.StringValue("secret"),
+		CatalogName:      types.StringValue("tabular"),
+		Endpoint:         types.StringNull(),
+	}
+	connection := newCatalogConnection(&data, "https://api.dev.tabular.io", "funhouse", "us-west-2")
+	assert.Equal(t, "tabular", connection.CatalogName)
+	assert.Equal(t, "https://api.dev.tabular.io/ws", connection.Uri)
+
+	// The top-level attributes leave the secret out, with_credential includes it
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.Equal(t, `spark.sql.catalog.tabular org.apache.iceberg.spark.SparkCatalog
+spark.sql.catalog.tabular.catalog-impl org.apache.iceberg.rest.RESTCatalog
+spark.sql.catalog.tabular.uri https://api.dev.tabular.io/ws
+spark.sql.catalog.tabular.warehouse funhouse
+spark.sql.catalog.tabular.oauth2-server-uri https://api.dev.tabular.io/ws/v1/oauth/tokens
+spark.sql.catalog.tabular.token-exchange-enabled true
+spark.sql.defaultCatalog tabular
+spark.sql.extensions org.apache.iceberg.spark.extensions.IcebergSparkSessionExtensions
+`, data.SparkDefaults.ValueString())
+	assert.NotContains(t, data.SparkConfig.ValueString(), "secret")
+	withCredential := withCredentialConfig(t, data)
+	assert.Contains(t, withCredential.SparkDefaults.ValueString(), "spark.sql.catalog.tabular.credential t-key:secret\n")
+	assert.Contains(t, withCredential.SparkConfig.ValueString(), `"spark.sql.catalog.tabular.credential": "t-key:secret"`)
+	assert.Contains(t, withCredential.SparkConfArgs.String(), "spark.sql.catalog.tabular.credential=t-key:secret")
+
+	data.Engine = types.StringValue(engineTrino)
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.NotContains(t, data.TrinoCatalog.ValueString(), "secret")
+	assert.Contains(t, withCredentialConfig(t, data).TrinoCatalog.ValueString(),
+		"iceberg.rest-catalog.security=OAUTH2\niceberg.rest-catalog.oauth2.credential=t-key:secret\n")
+
+	data.Engine = types.StringValue(engineDuckDB)
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.NotContains(t, data.DuckDBSQL.ValueString(), "CREATE SECRET")
+	assert.Contains(t, data.DuckDBSQL.ValueString(), "AUTHORIZATION_TYPE 'oauth2'")
+	assert.Contains(t, withCredentialConfig(t, data).DuckDBSQL.ValueString(), "CREATE SECRET (TYPE iceberg, CLIENT_ID 't-key', "+
+		"CLIENT_SECRET 'secret', OAUTH2_SERVER_URI 'https://api.dev.tabular.io/ws/v1/oauth/tokens');\n")
+}
+
+func withCredentialConfig(t *testing.T, data ComputeConfigDataSourceModel) computeEngineConfigModel {
+	var config computeEngineConfigModel
+	assert.False(t, data.WithCredential.As(context.Background(), &config, basetypes.ObjectAsOptions{}).HasError())
+	return config
+}
+
+func TestOAuthTokenEngineConfig(t *testing.T) {
+	data := ComputeConfigDataSourceModel{
+		Engine:           types.StringValue(enginePyIceberg),
+		AuthMode:         types.StringValue(authModeOAuthToken),
+		CredentialKey:    types.StringNull(),
+		CredentialSecret: types.StringValue("tok"),
+		CatalogName:      types.StringNull(),
+		Endpoint:         types.StringValue("https://catalog.example.com/ws"),
+	}
+	connection := newCatalogConnection(&data, defaultEndpoint, "funhouse", "us-west-2")
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.Equal(t, `catalog:
+  "funhouse":
+    type: "rest"
+    uri: "https://catalog.example.com/ws"
+    warehouse: "funhouse"
+    token: "tok"
+    oauth2-server-uri: "https://catalog.example.com/ws/v1/oauth/tokens"
+`, withCredentialConfig(t, data).PyIceberg.ValueString())
+	assert.NotContains(t, data.PyIceberg.ValueString(), "token:")
+
+	// Without a wired credential the engine supplies it
+	data.CredentialSecret = types.StringNull()
+	connection = newCatalogConnection(&data, defaultEndpoint, "funhouse", "us-west-2")
+	assert.False(t, setEngineConfig(context.Background(), &data, connection).HasError())
+	assert.NotContains(t, data.PyIceberg.ValueString(), "token:")
+	assert.True(t, data.WithCredential.IsNull())
 }
 
 func TestAccComputeConfigDataSource(t *testing.T) {
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"sort"
+)
+
+var _ datasource.DataSource = &DatabaseDataSource{}
+var _ datasource.DataSourceWithConfigure = &DatabaseDataSource{}
+
+func NewDatabaseDataSource() datasource.DataSource {
+	return &DatabaseDataSource{}
+}
+
+type DatabaseDataSource struct {
+	client *util.Client
+}
+
+type DatabaseDataSourceModel struct {
+	Id            types.String         `tfsdk:"id"`
+	WarehouseId   types.String         `tfsdk:"warehouse_id"`
+	WarehouseName types.String         `tfsdk:"warehouse_name"`
+	Name          types.String         `tfsdk:"name"`
+	Location      types.String         `tfsdk:"location"`
+	Properties    types.Map            `tfsdk:"properties"`
+	TableCount    types.Int64          `tfsdk:"table_count"`
+	GrantRoles    types.Set            `tfsdk:"grant_roles"`
+	Grants        []DatabaseGrantModel `tfsdk:"grants"`
+}
+
+type DatabaseGrantModel struct {
+	RoleName  types.String `tfsdk:"role_name"`
+	RoleId    types.String `tfsdk:"role_id"`
+	Privilege types.String `tfsdk:"privilege"`
+	WithGrant types.Bool   `tfsdk:"with_grant"`
+}
+
+func (d *DatabaseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+}
+
+func (d *DatabaseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tabular Database data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Database ID",
+				Computed:            true,
+			},
+			"warehouse_id": schema.StringAttribute{
+				MarkdownDescription: "Warehouse ID. Exactly one of `warehouse_id` or `warehouse_name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"warehouse_name": schema.StringAttribute{
+				MarkdownDescription: "Warehouse Name. Exactly one of `warehouse_id` or `warehouse_name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Database Name",
+				Required:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Storage Location",
+				Computed:            true,
+			},
+			"properties": schema.MapAttribute{
+				MarkdownDescription: "Database properties",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"table_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of tables in the database",
+				Computed:            true,
+			},
+			"grant_roles": schema.SetAttribute{
+				MarkdownDescription: "Role names to report the database grants of",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"grants": schema.ListNestedAttribute{
+				MarkdownDescription: "Privileges on the database granted to the roles in `grant_roles`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role_name": schema.StringAttribute{
+							MarkdownDescription: "Role Name",
+							Computed:            true,
+						},
+						"role_id": schema.StringAttribute{
+							MarkdownDescription: "Role ID",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege",
+							Computed:            true,
+						},
+						"with_grant": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege can be granted to other roles",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DatabaseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DatabaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DatabaseDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.WarehouseId.IsNull() == data.WarehouseName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("warehouse_id"),
+			"Invalid warehouse",
+			"Exactly one of warehouse_id or warehouse_name must be set",
+		)
+		return
+	}
+
+	if data.WarehouseId.IsNull() {
+		warehouse, err := findWarehouseByName(ctx, d.client, data.WarehouseName.ValueString(), false)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("warehouse_name"), "Warehouse not found", err.Error())
+			return
+		}
+		data.WarehouseId = types.StringValue(warehouse.GetId())
+	} else {
+		retryFunc := util.RetryResourceResponse[*tabularv2.GetWarehouseResponse]
+		warehouse, _, err := retryFunc(d.client.V2.DefaultAPI.GetWarehouse(ctx, *d.client.OrganizationId, data.WarehouseId.ValueString()).Execute)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("warehouse_id"), "Warehouse not found", err.Error())
+			return
+		}
+		data.WarehouseName = types.StringValue(warehouse.GetName())
+	}
+
+	warehouseId := data.WarehouseId.ValueString()
+	name := data.Name.ValueString()
+	retryFunc := util.RetryResourceResponse[*tabularv2.GetDatabaseResponse]
+	database, _, err := retryFunc(d.client.V2.DefaultAPI.GetDatabase(ctx, *d.client.OrganizationId, warehouseId, name).Execute)
+	if err != nil {
+		resp.Diagnostics.AddError("Database not found", fmt.Sprintf("Could not fetch database %s in warehouse %s: %s", name, warehouseId, err.Error()))
+		return
+	}
+
+	data.Id = types.StringValue(database.GetId())
+	properties := database.GetProperties()
+	data.Location = types.StringNull()
+	if location, ok := properties["location"]; ok {
+		data.Location = types.StringValue(location)
+	}
+	var diags diag.Diagnostics
+	data.Properties, diags = types.MapValueFrom(ctx, types.StringType, properties)
+	resp.Diagnostics.Append(diags...)
+
+	tables, err := d.client.V1.ListTables(warehouseId, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing tables", fmt.Sprintf("Could not list tables in database %s: %s", name, err.Error()))
+		return
+	}
+	data.TableCount = types.Int64Value(int64(len(tables)))
+
+	if !data.GrantRoles.IsNull() {
+		var roleNames []string
+		resp.Diagnostics.Append(data.GrantRoles.ElementsAs(ctx, &roleNames, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sort.Strings(roleNames)
+		data.Grants = make([]DatabaseGrantModel, 0)
+		for _, roleName := range roleNames {
+			role, _, err := d.client.V2.DefaultAPI.GetRole(ctx, *d.client.OrganizationId, roleName).Execute()
+			if err != nil {
+				resp.Diagnostics.AddError("Failed fetching role", "Could not fetch role "+roleName+": "+err.Error())
+				return
+			}
+			grantsFunc := util.RetryResourceResponse[*tabularv2.GetRoleDatabaseGrantsResponse]
+			grants, _, err := grantsFunc(d.client.V2.DefaultAPI.ListDatabaseRoleGrantsForRole(ctx, *d.client.OrganizationId, warehouseId, database.GetId(), role.GetId()).Execute)
+			if err != nil {
+				resp.Diagnostics.AddError("Error fetching database grants", "Could not fetch grants on "+name+" for role "+roleName+": "+err.Error())
+				return
+			}
+			for _, grant := range grants.Authorizations {
+				data.Grants = append(data.Grants, DatabaseGrantModel{
+					RoleName:  types.StringValue(roleName),
+					RoleId:    types.StringValue(role.GetId()),
+					Privilege: types.StringValue(grant.GetPrivilege()),
+					WithGrant: types.BoolValue(grant.GetWithGrant()),
+				})
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatabaseDataSource(t *testing.T) {
+	bucketName := os.Getenv("TABULAR_AWS_S3_BUCKET")
+	roleArn := os.Getenv("TABULAR_AWS_IAM_ROLE_ARN")
+	name := fmt.Sprintf("tf-acc-test-%d", rand.Intn(100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { accPreCheck(t) },
+		ProtoV6ProviderFactories: accProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabaseDataSourceConfig(bucketName, roleArn, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.tabular_database.test", "id", "tabular_database.test", "id"),
+					resource.TestCheckResourceAttrPair("data.tabular_database.test", "warehouse_id", "tabular_warehouse.test", "id"),
+					resource.TestCheckResourceAttrPair("data.tabular_database.test", "location", "tabular_database.test", "location"),
+					resource.TestCheckResourceAttr("data.tabular_database.test", "table_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabaseDataSourceConfig(bucketName, roleArn, name string) string {
+	return fmt.Sprintf(`
+resource "tabular_s3_storage_profile" "test" {
+  region = "us-west-2"
+  s3_bucket_name = "%s"
+  role_arn = "%s"
+}
+
+resource "tabular_warehouse" "test" {
+  name            = "%s"
+  storage_profile = tabular_s3_storage_profile.test.id
+}
+
+resource "tabular_database" "test" {
+  name         = "%s"
+  warehouse_id = tabular_warehouse.test.id
+}
+
+data "tabular_database" "test" {
+  warehouse_name = tabular_warehouse.test.name
+  name           = tabular_database.test.name
+}
+`, bucketName, roleArn, name, name)
+}
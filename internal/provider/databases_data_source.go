@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"sort"
+)
+
+var _ datasource.DataSource = &DatabasesDataSource{}
+var _ datasource.DataSourceWithConfigure = &DatabasesDataSource{}
+
+func NewDatabasesDataSource() datasource.DataSource {
+	return &DatabasesDataSource{}
+}
+
+type DatabasesDataSource struct {
+	client *util.Client
+}
+
+type DatabasesDataSourceModel struct {
+	WarehouseId types.String `tfsdk:"warehouse_id"`
+	NamePrefix  types.String `tfsdk:"name_prefix"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	Names       types.List   `tfsdk:"names"`
+}
+
+func (d *DatabasesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databases"
+}
+
+func (d *DatabasesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("databases")
+	attributes["warehouse_id"] = schema.StringAttribute{
+		MarkdownDescription: "Warehouse ID",
+		Required:            true,
+	}
+	attributes["names"] = schema.ListAttribute{
+		MarkdownDescription: "Database names, sorted. Nested databases are joined with `.`",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the databases in a warehouse",
+		Attributes:          attributes,
+	}
+}
+
+func (d *DatabasesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DatabasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DatabasesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newNameFilter(data.NamePrefix, data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name filter", err.Error())
+		return
+	}
+
+	warehouseId := data.WarehouseId.ValueString()
+	databases, err := d.client.V1.ListDatabases(warehouseId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing databases", "Could not list databases in warehouse "+warehouseId+": "+err.Error())
+		return
+	}
+	databases = internal.Filter(databases, filter.matches)
+	sort.Strings(databases)
+
+	names, diags := types.ListValueFrom(ctx, types.StringType, databases)
+	resp.Diagnostics.Append(diags...)
+	data.Names = names
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
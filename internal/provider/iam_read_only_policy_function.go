@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &IAMReadOnlyPolicyFunction{}
+
+func NewIAMReadOnlyPolicyFunction() function.Function {
+	return &IAMReadOnlyPolicyFunction{}
+}
+
+type IAMReadOnlyPolicyFunction struct{}
+
+func (f *IAMReadOnlyPolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "iam_read_only_policy"
+}
+
+func (f *IAMReadOnlyPolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Renders the IAM policy giving Tabular read access to a bucket",
+		MarkdownDescription: "Renders the IAM policy giving Tabular read-only access to an S3 bucket",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "bucket",
+				MarkdownDescription: "The storage bucket",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IAMReadOnlyPolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var bucket string
+	resp.Error = req.Arguments.Get(ctx, &bucket)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, IAMReadOnlyPolicy(bucket))
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &IAMReadWritePolicyFunction{}
+
+func NewIAMReadWritePolicyFunction() function.Function {
+	return &IAMReadWritePolicyFunction{}
+}
+
+type IAMReadWritePolicyFunction struct{}
+
+func (f *IAMReadWritePolicyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "iam_read_write_policy"
+}
+
+func (f *IAMReadWritePolicyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Renders the IAM policy giving Tabular read and write access to a bucket",
+		MarkdownDescription: "Renders the IAM policy giving Tabular read and write access to an S3 bucket. Attach it to the role the bucket's storage profile uses.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "bucket",
+				MarkdownDescription: "The storage bucket",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IAMReadWritePolicyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var bucket string
+	resp.Error = req.Arguments.Get(ctx, &bucket)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, IAMReadWritePolicy(bucket))
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &IAMRoleMappingSparkConfigFunction{}
+
+func NewIAMRoleMappingSparkConfigFunction() function.Function {
+	return &IAMRoleMappingSparkConfigFunction{}
+}
+
+type IAMRoleMappingSparkConfigFunction struct{}
+
+func (f *IAMRoleMappingSparkConfigFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "iam_role_mapping_spark_config"
+}
+
+func (f *IAMRoleMappingSparkConfigFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Renders EMR Spark configuration for a warehouse",
+		MarkdownDescription: "Renders EMR configuration classifications for Spark to reach a warehouse through Tabular's IAM gateway, which maps the cluster's IAM role to a Tabular role",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "warehouse_name",
+				MarkdownDescription: "Warehouse Name",
+			},
+			function.StringParameter{
+				Name:                "region",
+				MarkdownDescription: "The warehouse's AWS region",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IAMRoleMappingSparkConfigFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var warehouseName, region string
+	resp.Error = req.Arguments.Get(ctx, &warehouseName, &region)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, GetIAMRoleMappingSparkConfig(warehouseName, region))
+}
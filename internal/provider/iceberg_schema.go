@@ -0,0 +1,475 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// icebergNestedType is the union of Iceberg's struct, list and map types
+type icebergNestedType struct {
+	Type      string                `json:"type"`
+	Fields    []tabular.SchemaField `json:"fields"`
+	ElementId int                   `json:"element-id"`
+	Element   json.RawMessage       `json:"element"`
+	KeyId     int                   `json:"key-id"`
+	Key       json.RawMessage       `json:"key"`
+	ValueId   int                   `json:"value-id"`
+	Value     json.RawMessage       `json:"value"`
+	// ElementRequired and ValueRequired are only set on lists and maps respectively
+	ElementRequired bool `json:"element-required"`
+	ValueRequired   bool `json:"value-required"`
+}
+
+// icebergFieldNames maps every field id in the schema, including nested ones, to its dotted name. List elements and
+// map keys and values are named element, key and value, as Iceberg does.
+func icebergFieldNames(schema *tabular.Schema) map[int]string {
+	names := make(map[int]string)
+	for _, field := range schema.Fields {
+		names[field.Id] = field.Name
+		walkIcebergType(field.Name+".", field.Type, names)
+	}
+	return names
+}
+
+func walkIcebergType(prefix string, raw json.RawMessage, names map[int]string) {
+	if isPrimitiveIcebergType(raw) {
+		return
+	}
+	var nested icebergNestedType
+	if err := json.Unmarshal(raw, &nested); err != nil {
+		return
+	}
+
+	switch nested.Type {
+	case "struct":
+		for _, field := range nested.Fields {
+			names[field.Id] = prefix + field.Name
+			walkIcebergType(prefix+field.Name+".", field.Type, names)
+		}
+	case "list":
+		names[nested.ElementId] = prefix + "element"
+		walkIcebergType(prefix+"element.", nested.Element, names)
+	case "map":
+		names[nested.KeyId] = prefix + "key"
+		walkIcebergType(prefix+"key.", nested.Key, names)
+		names[nested.ValueId] = prefix + "value"
+		walkIcebergType(prefix+"value.", nested.Value, names)
+	}
+}
+
+// icebergTypeString renders a field type as its primitive type name, or as compact JSON for nested types
+func icebergTypeString(raw json.RawMessage) string {
+	if isPrimitiveIcebergType(raw) {
+		var name string
+		if err := json.Unmarshal(raw, &name); err == nil {
+			return name
+		}
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+	return compact.String()
+}
+
+func isPrimitiveIcebergType(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] == '"'
+}
+
+type icebergStructType struct {
+	Type   string                `json:"type"`
+	Fields []tabular.SchemaField `json:"fields"`
+}
+
+type icebergListType struct {
+	Type            string          `json:"type"`
+	ElementId       int             `json:"element-id"`
+	Element         json.RawMessage `json:"element"`
+	ElementRequired bool            `json:"element-required"`
+}
+
+type icebergMapType struct {
+	Type          string          `json:"type"`
+	KeyId         int             `json:"key-id"`
+	Key           json.RawMessage `json:"key"`
+	ValueId       int             `json:"value-id"`
+	Value         json.RawMessage `json:"value"`
+	ValueRequired bool            `json:"value-required"`
+}
+
+var icebergPrimitiveTypes = []string{
+	"boolean", "int", "long", "float", "double", "date", "time", "timestamp", "timestamptz", "timestamp_ns",
+	"timestamptz_ns", "string", "uuid", "binary",
+}
+
+var (
+	decimalTypePattern = regexp.MustCompile(`^decimal\(\s*(\d+)\s*,\s*(\d+)\s*\)$`)
+	fixedTypePattern   = regexp.MustCompile(`^fixed\[\s*(\d+)\s*]$`)
+)
+
+// normalizeIcebergPrimitiveType returns the type name as Iceberg writes it, e.g. decimal(10, 2) for decimal(10,2)
+func normalizeIcebergPrimitiveType(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if slices.Contains(icebergPrimitiveTypes, name) {
+		return name, nil
+	}
+	if match := decimalTypePattern.FindStringSubmatch(name); match != nil {
+		precision, _ := strconv.Atoi(match[1])
+		scale, _ := strconv.Atoi(match[2])
+		if precision < 1 || precision > 38 || scale > precision {
+			return "", fmt.Errorf("%s needs a precision between 1 and 38 and a scale no larger than the precision", name)
+		}
+		return fmt.Sprintf("decimal(%d, %d)", precision, scale), nil
+	}
+	if match := fixedTypePattern.FindStringSubmatch(name); match != nil {
+		length, _ := strconv.Atoi(match[1])
+		if length < 1 {
+			return "", fmt.Errorf("%s needs a length of at least 1", name)
+		}
+		return fmt.Sprintf("fixed[%d]", length), nil
+	}
+	return "", fmt.Errorf("%q is not an Iceberg type. Types are struct, list, map, decimal(P, S), fixed[L] or one of %s",
+		name, icebergPrimitiveTypes)
+}
+
+// icebergSchemaBuilder turns fields written as HCL into an Iceberg schema. Field ids are assigned the way Iceberg
+// assigns fresh ids: every field of a struct first, then the fields nested in each of them.
+type icebergSchemaBuilder struct {
+	lastId             int
+	identifierFieldIds []int
+}
+
+// buildIcebergSchema converts a list of fields, each an object with a name, type, and optionally required, doc and
+// identifier, into a schema. Struct fields list their own fields, lists have an element type and maps a key and value
+// type, each either a type name or an object with a type and the same nested attributes.
+func buildIcebergSchema(fields interface{}) (*tabular.Schema, error) {
+	builder := &icebergSchemaBuilder{}
+	schemaFields, err := builder.buildStruct(fields, "")
+	if err != nil {
+		return nil, err
+	}
+	return &tabular.Schema{Type: "struct", SchemaId: 0, IdentifierFieldIds: builder.identifierFieldIds, Fields: schemaFields}, nil
+}
+
+func (b *icebergSchemaBuilder) buildStruct(value interface{}, prefix string) ([]tabular.SchemaField, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%sfields must be a list of objects", prefix)
+	}
+
+	fields := make([]tabular.SchemaField, len(items))
+	specs := make([]map[string]interface{}, len(items))
+	names := make(map[string]bool)
+	for i, item := range items {
+		spec, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%sfields[%d] must be an object", prefix, i)
+		}
+		name, ok := spec["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%sfields[%d] needs a name", prefix, i)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("%s%s is defined more than once", prefix, name)
+		}
+		names[name] = true
+		required, err := optionalBool(spec, "required", prefix+name)
+		if err != nil {
+			return nil, err
+		}
+		doc, _ := spec["doc"].(string)
+
+		b.lastId++
+		fields[i] = tabular.SchemaField{Id: b.lastId, Name: name, Required: required, Doc: doc}
+		specs[i] = spec
+
+		identifier, err := optionalBool(spec, "identifier", prefix+name)
+		if err != nil {
+			return nil, err
+		}
+		if identifier {
+			if !required {
+				return nil, fmt.Errorf("%s%s is an identifier field, so it must be required", prefix, name)
+			}
+			b.identifierFieldIds = append(b.identifierFieldIds, b.lastId)
+		}
+	}
+
+	for i := range fields {
+		fieldType, err := b.buildType(specs[i], prefix+fields[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		fields[i].Type = fieldType
+	}
+	return fields, nil
+}
+
+// buildType converts a type name, or an object with a type and its nested attributes, to an Iceberg type
+func (b *icebergSchemaBuilder) buildType(value interface{}, name string) (json.RawMessage, error) {
+	if typeName, ok := value.(string); ok {
+		value = map[string]interface{}{"type": typeName}
+	}
+	spec, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a type name or an object with a type", name)
+	}
+	typeName, ok := spec["type"].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s needs a type", name)
+	}
+
+	switch strings.ToLower(typeName) {
+	case "struct":
+		if err := checkAttributes(spec, name, "id", "name", "type", "required", "doc", "identifier", "fields"); err != nil {
+			return nil, err
+		}
+		fields, err := b.buildStruct(spec["fields"], name+".")
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(icebergStructType{Type: "struct", Fields: fields})
+	case "list":
+		if err := checkAttributes(spec, name, "id", "name", "type", "required", "doc", "identifier", "element", "element_id", "element_required"); err != nil {
+			return nil, err
+		}
+		elementRequired, err := optionalBool(spec, "element_required", name)
+		if err != nil {
+			return nil, err
+		}
+		b.lastId++
+		list := icebergListType{Type: "list", ElementId: b.lastId, ElementRequired: elementRequired}
+		if list.Element, err = b.buildType(spec["element"], name+".element"); err != nil {
+			return nil, err
+		}
+		return json.Marshal(list)
+	case "map":
+		if err := checkAttributes(spec, name, "id", "name", "type", "required", "doc", "identifier", "key", "key_id", "value", "value_id", "value_required"); err != nil {
+			return nil, err
+		}
+		valueRequired, err := optionalBool(spec, "value_required", name)
+		if err != nil {
+			return nil, err
+		}
+		m := icebergMapType{Type: "map", KeyId: b.lastId + 1, ValueId: b.lastId + 2, ValueRequired: valueRequired}
+		b.lastId += 2
+		if m.Key, err = b.buildType(spec["key"], name+".key"); err != nil {
+			return nil, err
+		}
+		if m.Value, err = b.buildType(spec["value"], name+".value"); err != nil {
+			return nil, err
+		}
+		return json.Marshal(m)
+	default:
+		if err := checkAttributes(spec, name, "id", "name", "type", "required", "doc", "identifier"); err != nil {
+			return nil, err
+		}
+		primitive, err := normalizeIcebergPrimitiveType(typeName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return json.Marshal(primitive)
+	}
+}
+
+// checkAttributes catches misspelt attributes, which would otherwise be silently ignored
+func checkAttributes(spec map[string]interface{}, name string, allowed ...string) error {
+	for attribute, value := range spec {
+		if value != nil && !slices.Contains(allowed, attribute) {
+			return fmt.Errorf("%s has an unexpected attribute %s", name, attribute)
+		}
+	}
+	return nil
+}
+
+func optionalBool(spec map[string]interface{}, attribute, name string) (bool, error) {
+	value, ok := spec[attribute]
+	if !ok || value == nil {
+		return false, nil
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("%s.%s must be true or false", name, attribute)
+	}
+	return b, nil
+}
+
+// icebergSchemaValue converts a schema to the object buildIcebergSchema accepts, with the assigned ids added
+func icebergSchemaValue(schema *tabular.Schema) (attr.Value, error) {
+	fields, err := icebergFieldsValue(schema.Fields, schema.IdentifierFieldIds)
+	if err != nil {
+		return nil, err
+	}
+	identifierFieldIds := internal.Map(schema.IdentifierFieldIds, func(id int) attr.Value { return types.Int64Value(int64(id)) })
+	return newObjectValue(map[string]attr.Value{
+		"schema_id":            types.Int64Value(int64(schema.SchemaId)),
+		"identifier_field_ids": newTupleValue(identifierFieldIds),
+		"fields":               fields,
+	}), nil
+}
+
+func icebergFieldsValue(fields []tabular.SchemaField, identifierFieldIds []int) (attr.Value, error) {
+	values := make([]attr.Value, 0, len(fields))
+	for _, field := range fields {
+		attributes, err := icebergTypeAttributes(field.Type, identifierFieldIds)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		attributes["id"] = types.Int64Value(int64(field.Id))
+		attributes["name"] = types.StringValue(field.Name)
+		attributes["required"] = types.BoolValue(field.Required)
+		attributes["doc"] = types.StringNull()
+		if field.Doc != "" {
+			attributes["doc"] = types.StringValue(field.Doc)
+		}
+		attributes["identifier"] = types.BoolValue(slices.Contains(identifierFieldIds, field.Id))
+		values = append(values, newObjectValue(attributes))
+	}
+	return newTupleValue(values), nil
+}
+
+// icebergTypeAttributes returns the type attribute of a field, along with the attributes of nested types
+func icebergTypeAttributes(raw json.RawMessage, identifierFieldIds []int) (map[string]attr.Value, error) {
+	if isPrimitiveIcebergType(raw) {
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return nil, err
+		}
+		return map[string]attr.Value{"type": types.StringValue(name)}, nil
+	}
+
+	var nested icebergNestedType
+	if err := json.Unmarshal(raw, &nested); err != nil {
+		return nil, err
+	}
+	attributes := map[string]attr.Value{"type": types.StringValue(nested.Type)}
+	switch nested.Type {
+	case "struct":
+		fields, err := icebergFieldsValue(nested.Fields, identifierFieldIds)
+		if err != nil {
+			return nil, err
+		}
+		attributes["fields"] = fields
+	case "list":
+		element, err := icebergNestedTypeValue(nested.Element, identifierFieldIds)
+		if err != nil {
+			return nil, err
+		}
+		attributes["element"] = element
+		attributes["element_id"] = types.Int64Value(int64(nested.ElementId))
+		attributes["element_required"] = types.BoolValue(nested.ElementRequired)
+	case "map":
+		key, err := icebergNestedTypeValue(nested.Key, identifierFieldIds)
+		if err != nil {
+			return nil, err
+		}
+		value, err := icebergNestedTypeValue(nested.Value, identifierFieldIds)
+		if err != nil {
+			return nil, err
+		}
+		attributes["key"] = key
+		attributes["key_id"] = types.Int64Value(int64(nested.KeyId))
+		attributes["value"] = value
+		attributes["value_id"] = types.Int64Value(int64(nested.ValueId))
+		attributes["value_required"] = types.BoolValue(nested.ValueRequired)
+	default:
+		return nil, fmt.Errorf("unknown type %s", nested.Type)
+	}
+	return attributes, nil
+}
+
+// icebergNestedTypeValue returns a list element or map key or value type: a type name for primitives, else an object
+func icebergNestedTypeValue(raw json.RawMessage, identifierFieldIds []int) (attr.Value, error) {
+	attributes, err := icebergTypeAttributes(raw, identifierFieldIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(attributes) == 1 {
+		return attributes["type"], nil
+	}
+	return newObjectValue(attributes), nil
+}
+
+func newObjectValue(attributes map[string]attr.Value) attr.Value {
+	attributeTypes := make(map[string]attr.Type, len(attributes))
+	for name, value := range attributes {
+		attributeTypes[name] = value.Type(context.Background())
+	}
+	return types.ObjectValueMust(attributeTypes, attributes)
+}
+
+func newTupleValue(elements []attr.Value) attr.Value {
+	elementTypes := internal.Map(elements, func(element attr.Value) attr.Type { return element.Type(context.Background()) })
+	return types.TupleValueMust(elementTypes, elements)
+}
+
+// goValue converts a Terraform value to nil, a string, bool, *big.Float, []interface{} or map[string]interface{}
+func goValue(ctx context.Context, value attr.Value) (interface{}, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return goValue(ctx, v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Number:
+		return v.ValueBigFloat(), nil
+	case types.Int64:
+		return big.NewFloat(float64(v.ValueInt64())), nil
+	case types.Object:
+		return goMap(ctx, v.Attributes())
+	case types.Map:
+		return goMap(ctx, v.Elements())
+	case types.Tuple:
+		return goSlice(ctx, v.Elements())
+	case types.List:
+		return goSlice(ctx, v.Elements())
+	case types.Set:
+		return goSlice(ctx, v.Elements())
+	default:
+		return nil, fmt.Errorf("unsupported value %s", value)
+	}
+}
+
+func goMap(ctx context.Context, values map[string]attr.Value) (interface{}, error) {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		converted, err := goValue(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = converted
+	}
+	return result, nil
+}
+
+func goSlice(ctx context.Context, values []attr.Value) (interface{}, error) {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		converted, err := goValue(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
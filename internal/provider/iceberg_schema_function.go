@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &IcebergSchemaFunction{}
+
+func NewIcebergSchemaFunction() function.Function {
+	return &IcebergSchemaFunction{}
+}
+
+type IcebergSchemaFunction struct{}
+
+func (f *IcebergSchemaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "iceberg_schema"
+}
+
+func (f *IcebergSchemaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds Iceberg schema JSON from a list of fields",
+		MarkdownDescription: "Builds Iceberg schema JSON from a list of fields, assigning field IDs the way Iceberg does " +
+			"for a new table: every field of a struct first, then the fields nested in each of them.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "fields",
+				MarkdownDescription: "List of fields, each an object with a `name`, a `type` and optionally `required`, `doc` " +
+					"and `identifier`. `type` is a primitive type such as `long` or `decimal(10, 2)`, or `struct` with nested " +
+					"`fields`, `list` with an `element` type and optional `element_required`, or `map` with `key` and `value` " +
+					"types and optional `value_required`. Element, key and value types are a type name or an object with a " +
+					"`type` and its nested attributes.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *IcebergSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fields types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &fields)
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := goValue(ctx, fields)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	schema, err := buildIcebergSchema(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	schemaJson, err := json.Marshal(schema)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(schemaJson))
+}
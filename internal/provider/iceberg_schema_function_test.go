@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIcebergSchemaFunctionRun(t *testing.T) {
+	fields := newTupleValue([]attr.Value{
+		newObjectValue(map[string]attr.Value{"name": types.StringValue("id"), "type": types.StringValue("long"), "required": types.BoolValue(true)}),
+		newObjectValue(map[string]attr.Value{"name": types.StringValue("attrs"), "type": types.StringValue("map"), "key": types.StringValue("string"), "value": types.StringValue("string"), "doc": types.StringNull()}),
+	})
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(fields)})}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	(&IcebergSchemaFunction{}).Run(context.Background(), req, &resp)
+
+	assert.Nil(t, resp.Error)
+	assert.JSONEq(t, `{
+  "type": "struct",
+  "schema-id": 0,
+  "fields": [
+    {"id": 1, "name": "id", "required": true, "type": "long"},
+    {"id": 2, "name": "attrs", "required": false, "type": {
+      "type": "map", "key-id": 3, "key": "string", "value-id": 4, "value": "string", "value-required": false
+    }}
+  ]
+}`, resp.Result.Value().(types.String).ValueString())
+
+	req = function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(types.StringValue("long"))})}
+	resp = function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	(&IcebergSchemaFunction{}).Run(context.Background(), req, &resp)
+	assert.ErrorContains(t, resp.Error, "fields must be a list of objects")
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+const testIcebergSchema = `{
+  "type": "struct",
+  "schema-id": 0,
+  "fields": [
+    {"id": 1, "name": "id", "required": true, "type": "long"},
+    {"id": 2, "name": "location", "required": false, "type": {
+      "type": "struct",
+      "fields": [{"id": 5, "name": "lat", "required": false, "type": "double"}]
+    }},
+    {"id": 3, "name": "tags", "required": false, "type": {
+      "type": "list", "element-id": 6, "element-required": false, "element": "string"
+    }},
+    {"id": 4, "name": "attributes", "required": false, "type": {
+      "type": "map", "key-id": 7, "key": "string", "value-id": 8, "value-required": false, "value": {
+        "type": "struct", "fields": [{"id": 9, "name": "score", "required": false, "type": "int"}]
+      }
+    }}
+  ]
+}`
+
+func TestIcebergFieldNames(t *testing.T) {
+	var schema tabular.Schema
+	assert.NoError(t, json.Unmarshal([]byte(testIcebergSchema), &schema))
+
+	assert.Equal(t, map[int]string{
+		1: "id",
+		2: "location",
+		3: "tags",
+		4: "attributes",
+		5: "location.lat",
+		6: "tags.element",
+		7: "attributes.key",
+		8: "attributes.value",
+		9: "attributes.value.score",
+	}, icebergFieldNames(&schema))
+}
+
+func TestIcebergTypeString(t *testing.T) {
+	assert.Equal(t, "long", icebergTypeString(json.RawMessage(`"long"`)))
+	assert.Equal(t, `{"type":"list","element-id":6,"element":"string"}`,
+		icebergTypeString(json.RawMessage(`{"type": "list", "element-id": 6, "element": "string"}`)))
+}
+
+func TestIcebergSchemaRoundTrip(t *testing.T) {
+	var schema tabular.Schema
+	assert.NoError(t, json.Unmarshal([]byte(testIcebergSchema), &schema))
+
+	value, err := icebergSchemaValue(&schema)
+	assert.NoError(t, err)
+	parsed, err := goValue(context.Background(), value)
+	assert.NoError(t, err)
+
+	// The parsed fields, ids and all, build the same schema again
+	built, err := buildIcebergSchema(parsed.(map[string]interface{})["fields"])
+	assert.NoError(t, err)
+	builtJson, err := json.Marshal(built)
+	assert.NoError(t, err)
+	assert.JSONEq(t, testIcebergSchema, string(builtJson))
+}
+
+func TestBuildIcebergSchema(t *testing.T) {
+	schema, err := buildIcebergSchema([]interface{}{
+		map[string]interface{}{"name": "id", "type": "long", "required": true, "identifier": true},
+		map[string]interface{}{"name": "price", "type": "Decimal(10,2)", "doc": "Unit price"},
+		map[string]interface{}{"name": "tags", "type": "list", "element": map[string]interface{}{
+			"type": "struct", "fields": []interface{}{map[string]interface{}{"name": "tag", "type": "string"}},
+		}},
+	})
+	assert.NoError(t, err)
+	schemaJson, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "type": "struct",
+  "schema-id": 0,
+  "identifier-field-ids": [1],
+  "fields": [
+    {"id": 1, "name": "id", "required": true, "type": "long"},
+    {"id": 2, "name": "price", "required": false, "type": "decimal(10, 2)", "doc": "Unit price"},
+    {"id": 3, "name": "tags", "required": false, "type": {
+      "type": "list", "element-id": 4, "element-required": false, "element": {
+        "type": "struct", "fields": [{"id": 5, "name": "tag", "required": false, "type": "string"}]
+      }
+    }}
+  ]
+}`, string(schemaJson))
+
+	_, err = buildIcebergSchema([]interface{}{map[string]interface{}{"name": "id", "type": "bigint"}})
+	assert.ErrorContains(t, err, `"bigint" is not an Iceberg type`)
+
+	_, err = buildIcebergSchema([]interface{}{map[string]interface{}{"name": "id", "type": "long", "identifier": true}})
+	assert.ErrorContains(t, err, "id is an identifier field, so it must be required")
+
+	_, err = buildIcebergSchema([]interface{}{map[string]interface{}{"name": "id", "type": "long", "requried": true}})
+	assert.ErrorContains(t, err, "unexpected attribute requried")
+
+	_, err = buildIcebergSchema([]interface{}{map[string]interface{}{"name": "m", "type": "map", "key": "string"}})
+	assert.ErrorContains(t, err, "m.value must be a type name")
+}
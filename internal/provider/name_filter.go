@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"strings"
+)
+
+// nameFilter narrows the objects returned by the list data sources down by name
+type nameFilter struct {
+	prefix string
+	regex  *regexp.Regexp
+}
+
+func newNameFilter(prefix, regex types.String) (*nameFilter, error) {
+	filter := &nameFilter{prefix: prefix.ValueString()}
+	if regex.ValueString() != "" {
+		compiled, err := regexp.Compile(regex.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid name_regex %q: %w", regex.ValueString(), err)
+		}
+		filter.regex = compiled
+	}
+	return filter, nil
+}
+
+func (f *nameFilter) matches(name string) bool {
+	if !strings.HasPrefix(name, f.prefix) {
+		return false
+	}
+	return f.regex == nil || f.regex.MatchString(name)
+}
+
+// nameFilterAttributes are the filter attributes shared by the list data sources
+func nameFilterAttributes(objects string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name_prefix": schema.StringAttribute{
+			MarkdownDescription: "Only return " + objects + " whose name starts with this prefix",
+			Optional:            true,
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: "Only return " + objects + " whose name matches this regular expression",
+			Optional:            true,
+		},
+	}
+}
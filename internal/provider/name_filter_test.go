@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameFilter(t *testing.T) {
+	filter, err := newNameFilter(types.StringNull(), types.StringNull())
+	assert.NoError(t, err)
+	assert.True(t, filter.matches("anything"))
+
+	filter, err = newNameFilter(types.StringValue("prod_"), types.StringValue("_(events|users)$"))
+	assert.NoError(t, err)
+	assert.True(t, filter.matches("prod_events"))
+	assert.False(t, filter.matches("dev_events"))
+	assert.False(t, filter.matches("prod_orders"))
+
+	_, err = newNameFilter(types.StringNull(), types.StringValue("("))
+	assert.Error(t, err)
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	"sort"
+	"strings"
+)
+
+var _ datasource.DataSource = &OrgMembersDataSource{}
+var _ datasource.DataSourceWithConfigure = &OrgMembersDataSource{}
+
+func NewOrgMembersDataSource() datasource.DataSource {
+	return &OrgMembersDataSource{}
+}
+
+type OrgMembersDataSource struct {
+	client *util.Client
+}
+
+type OrgMembersDataSourceModel struct {
+	EmailDomains        types.Set        `tfsdk:"email_domains"`
+	ExcludeEmailDomains types.Set        `tfsdk:"exclude_email_domains"`
+	IncludeRoles        types.Bool       `tfsdk:"include_roles"`
+	Emails              types.List       `tfsdk:"emails"`
+	Members             []OrgMemberModel `tfsdk:"members"`
+}
+
+type OrgMemberModel struct {
+	Id         types.String `tfsdk:"id"`
+	Email      types.String `tfsdk:"email"`
+	Roles      types.List   `tfsdk:"roles"`
+	AdminRoles types.List   `tfsdk:"admin_roles"`
+}
+
+func (d *OrgMembersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_members"
+}
+
+func (d *OrgMembersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the members of the organization, optionally with the roles they belong to",
+
+		Attributes: map[string]schema.Attribute{
+			"email_domains": schema.SetAttribute{
+				MarkdownDescription: "Only return members whose email is in one of these domains, e.g. `example.com`",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"exclude_email_domains": schema.SetAttribute{
+				MarkdownDescription: "Leave out members whose email is in one of these domains",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"include_roles": schema.BoolAttribute{
+				MarkdownDescription: "Report each member's `roles` and `admin_roles`. Roles only know their members, so this " +
+					"reads every role in the org. Defaults to false.",
+				Optional: true,
+			},
+			"emails": schema.ListAttribute{
+				MarkdownDescription: "Member emails, sorted",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "Members, sorted by email",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Member ID",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Member Email",
+							Computed:            true,
+						},
+						"roles": schema.ListAttribute{
+							MarkdownDescription: "Names of the roles the member belongs to, sorted. Only set with `include_roles`.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"admin_roles": schema.ListAttribute{
+							MarkdownDescription: "Names of the roles the member can administer, sorted. Only set with `include_roles`.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrgMembersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrgMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrgMembersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var domains, excludedDomains []string
+	resp.Diagnostics.Append(data.EmailDomains.ElementsAs(ctx, &domains, false)...)
+	resp.Diagnostics.Append(data.ExcludeEmailDomains.ElementsAs(ctx, &excludedDomains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgMembers, err := d.client.V1.GetOrgMembers()
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching org members", err.Error())
+		return
+	}
+	orgMembers = internal.Filter(orgMembers, func(m tabular.Member) bool {
+		return (data.EmailDomains.IsNull() || inEmailDomains(m.Email, domains)) && !inEmailDomains(m.Email, excludedDomains)
+	})
+	sort.Slice(orgMembers, func(i, j int) bool { return orgMembers[i].Email < orgMembers[j].Email })
+
+	var memberships map[string]roleMemberships
+	if data.IncludeRoles.ValueBool() {
+		memberships, err = d.readRoleMemberships()
+		if err != nil {
+			resp.Diagnostics.AddError("Error fetching roles", err.Error())
+			return
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Members = make([]OrgMemberModel, 0, len(orgMembers))
+	for _, orgMember := range orgMembers {
+		member := OrgMemberModel{
+			Id:         types.StringValue(orgMember.Id),
+			Email:      types.StringValue(orgMember.Email),
+			Roles:      types.ListNull(types.StringType),
+			AdminRoles: types.ListNull(types.StringType),
+		}
+		if memberships != nil {
+			membership, ok := memberships[orgMember.Id]
+			if !ok {
+				membership = roleMemberships{roles: []string{}, adminRoles: []string{}}
+			}
+			member.Roles, diags = types.ListValueFrom(ctx, types.StringType, membership.roles)
+			resp.Diagnostics.Append(diags...)
+			member.AdminRoles, diags = types.ListValueFrom(ctx, types.StringType, membership.adminRoles)
+			resp.Diagnostics.Append(diags...)
+		}
+		data.Members = append(data.Members, member)
+	}
+	data.Emails, diags = types.ListValueFrom(ctx, types.StringType, internal.Map(orgMembers, func(m tabular.Member) string { return m.Email }))
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readRoleMemberships reads every role, since roles only know their members
+func (d *OrgMembersDataSource) readRoleMemberships() (map[string]roleMemberships, error) {
+	roleNames, err := d.client.V1.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]tabular.Role, 0, len(roleNames))
+	for _, r := range roleNames {
+		role, err := d.client.V1.GetRole(r.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch role %s: %w", r.Name, err)
+		}
+		if role != nil {
+			roles = append(roles, *role)
+		}
+	}
+	return roleMembershipsByMember(roles), nil
+}
+
+type roleMemberships struct {
+	roles      []string
+	adminRoles []string
+}
+
+// roleMembershipsByMember inverts the members of each role into the sorted role names of each member id. Members
+// who administer a role are listed in both roles and adminRoles.
+func roleMembershipsByMember(roles []tabular.Role) map[string]roleMemberships {
+	memberships := make(map[string]roleMemberships)
+	for _, role := range roles {
+		for _, member := range role.Members {
+			membership, ok := memberships[member.Id]
+			if !ok {
+				membership = roleMemberships{roles: []string{}, adminRoles: []string{}}
+			}
+			membership.roles = append(membership.roles, role.Name)
+			if member.WithAdmin {
+				membership.adminRoles = append(membership.adminRoles, role.Name)
+			}
+			memberships[member.Id] = membership
+		}
+	}
+	for _, membership := range memberships {
+		sort.Strings(membership.roles)
+		sort.Strings(membership.adminRoles)
+	}
+	return memberships
+}
+
+// inEmailDomains reports whether email belongs to one of domains, ignoring case
+func inEmailDomains(email string, domains []string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	return slices.ContainsFunc(domains, func(d string) bool {
+		return strings.EqualFold(strings.TrimPrefix(d, "@"), domain)
+	})
+}
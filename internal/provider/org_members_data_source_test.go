@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func TestRoleMembershipsByMember(t *testing.T) {
+	memberships := roleMembershipsByMember([]tabular.Role{
+		{Name: "writers", Members: []tabular.Member{{Id: "1", WithAdmin: true}}},
+		{Name: "readers", Members: []tabular.Member{{Id: "1"}, {Id: "2"}}},
+	})
+
+	assert.Equal(t, []string{"readers", "writers"}, memberships["1"].roles)
+	assert.Equal(t, []string{"writers"}, memberships["1"].adminRoles)
+	assert.Equal(t, []string{"readers"}, memberships["2"].roles)
+	assert.Equal(t, []string{}, memberships["2"].adminRoles)
+}
+
+func TestInEmailDomains(t *testing.T) {
+	assert.True(t, inEmailDomains("jo@Example.com", []string{"example.com"}))
+	assert.True(t, inEmailDomains("jo@example.com", []string{"@example.com"}))
+	assert.False(t, inEmailDomains("jo@sub.example.com", []string{"example.com"}))
+	assert.False(t, inEmailDomains("jo@example.com", nil))
+	assert.False(t, inEmailDomains("not-an-email", []string{"example.com"}))
+}
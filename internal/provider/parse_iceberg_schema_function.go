@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+var _ function.Function = &ParseIcebergSchemaFunction{}
+
+func NewParseIcebergSchemaFunction() function.Function {
+	return &ParseIcebergSchemaFunction{}
+}
+
+type ParseIcebergSchemaFunction struct{}
+
+func (f *ParseIcebergSchemaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_iceberg_schema"
+}
+
+func (f *ParseIcebergSchemaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses Iceberg schema JSON into an object",
+		MarkdownDescription: "Parses Iceberg schema JSON into an object with `schema_id`, `identifier_field_ids` and " +
+			"`fields`. Fields have the attributes `iceberg_schema` accepts, plus their assigned `id`, and nested " +
+			"`element_id`, `key_id` and `value_id`, so `fields` can be passed back to `iceberg_schema`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "schema",
+				MarkdownDescription: "Iceberg schema JSON, such as a table data source's `schema_json`",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *ParseIcebergSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schemaJson string
+	resp.Error = req.Arguments.Get(ctx, &schemaJson)
+	if resp.Error != nil {
+		return
+	}
+
+	var schema tabular.Schema
+	if err := json.Unmarshal([]byte(schemaJson), &schema); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid schema JSON: "+err.Error())
+		return
+	}
+	if schema.Type != "struct" {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid schema JSON: a schema's type must be struct")
+		return
+	}
+	value, err := icebergSchemaValue(&schema)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid schema JSON: "+err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(value))
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var _ function.Function = &PartitionTransformFunction{}
+
+var partitionTransformAttrTypes = map[string]attr.Type{
+	"source_column": types.StringType,
+	"transform":     types.StringType,
+	"name":          types.StringType,
+}
+
+var (
+	transformCallPattern   = regexp.MustCompile(`^(\w+)\s*\((.*)\)$`)
+	transformColumnPattern = regexp.MustCompile("^(`[^`]+`|[A-Za-z_][A-Za-z0-9_]*)(\\.(`[^`]+`|[A-Za-z_][A-Za-z0-9_]*))*$")
+)
+
+// partitionTransform is a partition field as Iceberg stores it
+type partitionTransform struct {
+	SourceColumn string
+	Transform    string
+	Name         string
+}
+
+func NewPartitionTransformFunction() function.Function {
+	return &PartitionTransformFunction{}
+}
+
+type PartitionTransformFunction struct{}
+
+func (f *PartitionTransformFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "partition_transform"
+}
+
+func (f *PartitionTransformFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validates a partition transform expression",
+		MarkdownDescription: "Validates a partition transform expression such as `bucket(16, id)` or `day(ts)` and " +
+			"returns its `source_column`, its Iceberg `transform`, e.g. `bucket[16]`, and the `name` Iceberg gives the " +
+			"partition field by default. Fails on invalid expressions, so wrap it in `can` to check one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "expression",
+				MarkdownDescription: "A column name, or one of `identity(col)`, `bucket(N, col)`, `truncate(W, col)`, " +
+					"`year(col)`, `month(col)`, `day(col)`, `hour(col)` and `void(col)`. The plural forms Spark accepts, " +
+					"such as `days(col)`, are allowed too.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: partitionTransformAttrTypes},
+	}
+}
+
+func (f *PartitionTransformFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expression string
+	resp.Error = req.Arguments.Get(ctx, &expression)
+	if resp.Error != nil {
+		return
+	}
+
+	transform, err := parsePartitionTransform(expression)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, types.ObjectValueMust(partitionTransformAttrTypes, map[string]attr.Value{
+		"source_column": types.StringValue(transform.SourceColumn),
+		"transform":     types.StringValue(transform.Transform),
+		"name":          types.StringValue(transform.Name),
+	}))
+}
+
+func parsePartitionTransform(expression string) (*partitionTransform, error) {
+	expression = strings.TrimSpace(expression)
+	if transformColumnPattern.MatchString(expression) {
+		column := unquoteColumn(expression)
+		return &partitionTransform{SourceColumn: column, Transform: "identity", Name: column}, nil
+	}
+
+	match := transformCallPattern.FindStringSubmatch(expression)
+	if match == nil {
+		return nil, fmt.Errorf("%q is not a column or a transform such as bucket(16, id) or day(ts)", expression)
+	}
+	args := strings.Split(match[2], ",")
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+
+	name := strings.ToLower(match[1])
+	switch name {
+	case "bucket", "truncate":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s takes a width and a column, e.g. %s(16, id)", name, name)
+		}
+		width, err := strconv.Atoi(args[0])
+		if err != nil || width < 1 || width > math.MaxInt32 {
+			return nil, fmt.Errorf("%s's width must be a positive integer, not %q", name, args[0])
+		}
+		column, err := partitionSourceColumn(name, args[1])
+		if err != nil {
+			return nil, err
+		}
+		suffix := "_bucket"
+		if name == "truncate" {
+			suffix = "_trunc"
+		}
+		return &partitionTransform{SourceColumn: column, Transform: fmt.Sprintf("%s[%d]", name, width), Name: column + suffix}, nil
+	case "identity", "year", "years", "month", "months", "day", "days", "date", "hour", "hours", "date_hour", "void":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s takes a single column, e.g. %s(ts)", name, name)
+		}
+		column, err := partitionSourceColumn(name, args[0])
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "identity":
+			return &partitionTransform{SourceColumn: column, Transform: "identity", Name: column}, nil
+		case "void":
+			return &partitionTransform{SourceColumn: column, Transform: "void", Name: column + "_null"}, nil
+		case "date":
+			name = "day"
+		case "date_hour":
+			name = "hour"
+		}
+		transform := strings.TrimSuffix(name, "s")
+		return &partitionTransform{SourceColumn: column, Transform: transform, Name: column + "_" + transform}, nil
+	default:
+		return nil, fmt.Errorf("%s is not a partition transform. Transforms are identity, bucket, truncate, year, month, day, hour and void", match[1])
+	}
+}
+
+func partitionSourceColumn(transform, arg string) (string, error) {
+	if !transformColumnPattern.MatchString(arg) {
+		return "", fmt.Errorf("%s's argument %q is not a column name", transform, arg)
+	}
+	return unquoteColumn(arg), nil
+}
+
+// unquoteColumn removes the backticks quoting parts of a dotted column name
+func unquoteColumn(column string) string {
+	return strings.ReplaceAll(column, "`", "")
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePartitionTransform(t *testing.T) {
+	for expression, expected := range map[string]partitionTransform{
+		"region":                 {SourceColumn: "region", Transform: "identity", Name: "region"},
+		"identity(region)":       {SourceColumn: "region", Transform: "identity", Name: "region"},
+		"bucket(16, id)":         {SourceColumn: "id", Transform: "bucket[16]", Name: "id_bucket"},
+		"truncate(4,name)":       {SourceColumn: "name", Transform: "truncate[4]", Name: "name_trunc"},
+		"day(ts)":                {SourceColumn: "ts", Transform: "day", Name: "ts_day"},
+		"HOURS(event.ts)":        {SourceColumn: "event.ts", Transform: "hour", Name: "event.ts_hour"},
+		"months( `order date` )": {SourceColumn: "order date", Transform: "month", Name: "order date_month"},
+		"void(ts)":               {SourceColumn: "ts", Transform: "void", Name: "ts_null"},
+	} {
+		transform, err := parsePartitionTransform(expression)
+		if assert.NoError(t, err, expression) {
+			assert.Equal(t, expected, *transform, expression)
+		}
+	}
+
+	for expression, message := range map[string]string{
+		"bucket(id)":    "bucket takes a width and a column",
+		"bucket(0, id)": "bucket's width must be a positive integer",
+		"day(ts, 1)":    "day takes a single column",
+		"week(ts)":      "week is not a partition transform",
+		"day(1ts)":      `day's argument "1ts" is not a column name`,
+		"id + 1":        "is not a column or a transform",
+		"bucket(16, id": "is not a column or a transform",
+	} {
+		_, err := parsePartitionTransform(expression)
+		assert.ErrorContains(t, err, message, expression)
+	}
+}
+
+func TestPartitionTransformFunctionRun(t *testing.T) {
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("bucket(8, user_id)")})}
+	resp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(partitionTransformAttrTypes))}
+	(&PartitionTransformFunction{}).Run(context.Background(), req, &resp)
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.ObjectValueMust(partitionTransformAttrTypes, map[string]attr.Value{
+		"source_column": types.StringValue("user_id"),
+		"transform":     types.StringValue("bucket[8]"),
+		"name":          types.StringValue("user_id_bucket"),
+	}), resp.Result.Value())
+}
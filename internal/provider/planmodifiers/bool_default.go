@@ -0,0 +1,32 @@
+package planmodifiers
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type BoolDefaultModifier struct {
+	Default bool
+}
+
+var (
+	_ planmodifier.Bool = &BoolDefaultModifier{}
+)
+
+func (m BoolDefaultModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Defaults to %t", m.Default)
+}
+
+func (m BoolDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m BoolDefaultModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	resp.PlanValue = types.BoolValue(m.Default)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -21,6 +22,7 @@ var defaultEndpoint = "https://api.tabular.io"
 var defaultTokenEndpoint = "https://api.tabular.io/ws/v1/oauth/tokens"
 
 var _ provider.Provider = &TabularProvider{}
+var _ provider.ProviderWithFunctions = &TabularProvider{}
 
 type TabularProvider struct {
 	Version string
@@ -190,13 +192,19 @@ func (p *TabularProvider) Resources(ctx context.Context) []func() resource.Resou
 		NewDatabaseResource,
 		NewRoleResource,
 		NewRoleRelationshipResource,
+		NewRoleHierarchyResource,
 		NewRoleDatabaseGrantsResource,
 		NewRoleMembershipResource,
+		NewRoleMemberResource,
+		NewRoleMembershipRuleResource,
 		NewWarehouseResource,
 		NewStorageProfileS3Resource,
 		NewRoleWarehouseGrantsResource,
 		NewServiceAccountResource,
 		NewAWSRoleMappingResource,
+		NewViewResource,
+		NewTableMaintenanceResource,
+		NewTableRefResource,
 	}
 }
 
@@ -205,8 +213,31 @@ func (p *TabularProvider) DataSources(ctx context.Context) []func() datasource.D
 		NewAWSIAMPolicyDataSource,
 		NewComputeConfigDataSource,
 		NewWarehouseDataSource,
+		NewWarehousesDataSource,
+		NewDatabaseDataSource,
+		NewDatabasesDataSource,
+		NewTableDataSource,
+		NewTablesDataSource,
 		NewRoleDataSource,
+		NewRolesDataSource,
+		NewRoleEffectivePrivilegesDataSource,
+		NewRoleGraphDataSource,
+		NewOrgMembersDataSource,
 		NewS3StorageProfileDataSource,
+		NewStorageProfilesDataSource,
+		NewServiceAccountsDataSource,
+	}
+}
+
+func (p *TabularProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewIcebergSchemaFunction,
+		NewParseIcebergSchemaFunction,
+		NewPartitionTransformFunction,
+		NewIAMReadWritePolicyFunction,
+		NewIAMReadOnlyPolicyFunction,
+		NewAssumeRolePolicyFunction,
+		NewIAMRoleMappingSparkConfigFunction,
 	}
 }
 
@@ -5,8 +5,14 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
 	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	"sort"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,8 +30,31 @@ type RoleDataSource struct {
 
 // RoleDataSourceModel describes the data source data model.
 type RoleDataSourceModel struct {
-	Id   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	Id                     types.String              `tfsdk:"id"`
+	Name                   types.String              `tfsdk:"name"`
+	IncludeParents         types.Bool                `tfsdk:"include_parents"`
+	IncludeServiceAccounts types.Bool                `tfsdk:"include_service_accounts"`
+	Children               types.Set                 `tfsdk:"children"`
+	Parents                types.Set                 `tfsdk:"parents"`
+	AdminMembers           types.Set                 `tfsdk:"admin_members"`
+	Members                types.Set                 `tfsdk:"members"`
+	ServiceAccounts        types.Set                 `tfsdk:"service_accounts"`
+	WarehouseIds           types.Set                 `tfsdk:"warehouse_ids"`
+	WarehouseGrants        []RoleWarehouseGrantModel `tfsdk:"warehouse_grants"`
+	DatabaseGrants         []RoleDatabaseGrantModel  `tfsdk:"database_grants"`
+}
+
+type RoleWarehouseGrantModel struct {
+	WarehouseId types.String `tfsdk:"warehouse_id"`
+	Privilege   types.String `tfsdk:"privilege"`
+	WithGrant   types.Bool   `tfsdk:"with_grant"`
+}
+
+type RoleDatabaseGrantModel struct {
+	WarehouseId types.String `tfsdk:"warehouse_id"`
+	Database    types.String `tfsdk:"database"`
+	Privilege   types.String `tfsdk:"privilege"`
+	WithGrant   types.Bool   `tfsdk:"with_grant"`
 }
 
 func (d *RoleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -46,6 +75,89 @@ func (d *RoleDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Role Name",
 				Required:            true,
 			},
+			"include_parents": schema.BoolAttribute{
+				MarkdownDescription: "Report `parents`. Roles only know their children, so this reads every role in the org. Defaults to false.",
+				Optional:            true,
+			},
+			"include_service_accounts": schema.BoolAttribute{
+				MarkdownDescription: "Report service account members in `service_accounts` rather than in `admin_members` and " +
+					"`members`. This lists every credential in the org. Defaults to false.",
+				Optional: true,
+			},
+			"children": schema.SetAttribute{
+				MarkdownDescription: "Names of the role's child roles",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"parents": schema.SetAttribute{
+				MarkdownDescription: "Names of the roles that have this role as a child. Only set with `include_parents`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"admin_members": schema.SetAttribute{
+				MarkdownDescription: "Emails of the members who can administer the role",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"members": schema.SetAttribute{
+				MarkdownDescription: "Emails of the role's other members",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"service_accounts": schema.SetAttribute{
+				MarkdownDescription: "Credential keys of the service accounts bound to the role. Only set with `include_service_accounts`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"warehouse_ids": schema.SetAttribute{
+				MarkdownDescription: "Warehouse IDs to report the role's warehouse and database grants for",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"warehouse_grants": schema.ListNestedAttribute{
+				MarkdownDescription: "Privileges granted to the role on the warehouses in `warehouse_ids`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"warehouse_id": schema.StringAttribute{
+							MarkdownDescription: "Warehouse ID",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege",
+							Computed:            true,
+						},
+						"with_grant": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege can be granted to other roles",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"database_grants": schema.ListNestedAttribute{
+				MarkdownDescription: "Privileges granted to the role on the databases of the warehouses in `warehouse_ids`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"warehouse_id": schema.StringAttribute{
+							MarkdownDescription: "Warehouse ID",
+							Computed:            true,
+						},
+						"database": schema.StringAttribute{
+							MarkdownDescription: "Database Name",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege",
+							Computed:            true,
+						},
+						"with_grant": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege can be granted to other roles",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -91,5 +203,138 @@ func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	data.Id = types.StringValue(*role.Id)
 	data.Name = types.StringValue(*role.Name)
 
+	var serviceAccounts []tabular.Credential
+	if data.IncludeServiceAccounts.ValueBool() {
+		serviceAccounts, err = d.client.V1.GetServiceAccounts()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+			return
+		}
+	}
+	adminMembers, members, serviceAccountKeys := partitionRoleMembers(role.Members, serviceAccounts)
+	children := internal.Map(role.Children, func(c tabularv2.RoleRef) string { return c.GetName() })
+
+	var diags diag.Diagnostics
+	data.Children, diags = types.SetValueFrom(ctx, types.StringType, children)
+	resp.Diagnostics.Append(diags...)
+	data.AdminMembers, diags = types.SetValueFrom(ctx, types.StringType, adminMembers)
+	resp.Diagnostics.Append(diags...)
+	data.Members, diags = types.SetValueFrom(ctx, types.StringType, members)
+	resp.Diagnostics.Append(diags...)
+	data.ServiceAccounts = types.SetNull(types.StringType)
+	if data.IncludeServiceAccounts.ValueBool() {
+		data.ServiceAccounts, diags = types.SetValueFrom(ctx, types.StringType, serviceAccountKeys)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	data.Parents = types.SetNull(types.StringType)
+	if data.IncludeParents.ValueBool() {
+		parents, err := d.findParents(data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error fetching parent roles", err.Error())
+			return
+		}
+		data.Parents, diags = types.SetValueFrom(ctx, types.StringType, parents)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if !data.WarehouseIds.IsNull() {
+		var warehouseIds []string
+		resp.Diagnostics.Append(data.WarehouseIds.ElementsAs(ctx, &warehouseIds, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sort.Strings(warehouseIds)
+		data.WarehouseGrants = make([]RoleWarehouseGrantModel, 0)
+		data.DatabaseGrants = make([]RoleDatabaseGrantModel, 0)
+		for _, warehouseId := range warehouseIds {
+			d.readGrants(ctx, &data, warehouseId, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// findParents returns the roles that have roleName as a direct child. Roles only know their children, so this reads
+// every role.
+func (d *RoleDataSource) findParents(roleName string) ([]string, error) {
+	roleNames, err := d.client.V1.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]tabular.Role, 0, len(roleNames))
+	for _, r := range roleNames {
+		role, err := d.client.V1.GetRole(r.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch role %s: %w", r.Name, err)
+		}
+		if role != nil {
+			roles = append(roles, *role)
+		}
+	}
+	return parentRoleNames(roles, roleName), nil
+}
+
+// readGrants appends the role's grants on the warehouse and each of its databases to data
+func (d *RoleDataSource) readGrants(ctx context.Context, data *RoleDataSourceModel, warehouseId string, diags *diag.Diagnostics) {
+	warehouseDatabases, err := readWarehouseDatabases(ctx, d.client, warehouseId, nil)
+	if err != nil {
+		diags.AddError("Error fetching databases", err.Error())
+		return
+	}
+	grants, err := warehouseDatabases.readRoleGrants(ctx, d.client, data.Id.ValueString())
+	if err != nil {
+		diags.AddError("Error fetching grants", err.Error())
+		return
+	}
+
+	for _, grant := range grants.Warehouse {
+		data.WarehouseGrants = append(data.WarehouseGrants, RoleWarehouseGrantModel{
+			WarehouseId: types.StringValue(warehouseId),
+			Privilege:   types.StringValue(grant.GetPrivilege()),
+			WithGrant:   types.BoolValue(grant.GetWithGrant()),
+		})
+	}
+	for _, database := range warehouseDatabases.names {
+		for _, grant := range grants.Databases[database] {
+			data.DatabaseGrants = append(data.DatabaseGrants, RoleDatabaseGrantModel{
+				WarehouseId: types.StringValue(warehouseId),
+				Database:    types.StringValue(database),
+				Privilege:   types.StringValue(grant.GetPrivilege()),
+				WithGrant:   types.BoolValue(grant.GetWithGrant()),
+			})
+		}
+	}
+}
+
+// partitionRoleMembers splits role members into admin member emails, other member emails and the keys of the
+// service accounts among them
+func partitionRoleMembers(roleMembers []tabularv2.MemberEntry, serviceAccounts []tabular.Credential) (adminMembers, members, serviceAccountKeys []string) {
+	adminMembers, members, serviceAccountKeys = make([]string, 0), make([]string, 0), make([]string, 0)
+	for _, member := range roleMembers {
+		i := slices.IndexFunc(serviceAccounts, func(c tabular.Credential) bool { return c.MemberId == member.GetId() })
+		switch {
+		case i >= 0:
+			serviceAccountKeys = append(serviceAccountKeys, serviceAccounts[i].Key)
+		case member.GetWithAdmin():
+			adminMembers = append(adminMembers, member.GetEmail())
+		default:
+			members = append(members, member.GetEmail())
+		}
+	}
+	return adminMembers, members, serviceAccountKeys
+}
+
+// parentRoleNames returns the names of the roles with roleName as a direct child
+func parentRoleNames(roles []tabular.Role, roleName string) []string {
+	parents := make([]string, 0)
+	for _, role := range roles {
+		if slices.ContainsFunc(role.Children, func(c tabular.Role) bool { return c.Name == roleName }) {
+			parents = append(parents, role.Name)
+		}
+	}
+	return parents
+}
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
 )
 
 func TestAccRoleDataSource(t *testing.T) {
@@ -15,6 +18,8 @@ func TestAccRoleDataSource(t *testing.T) {
 				Config: testAccRoleDataSourceConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.tabular_role.test", "name", "Terraform"),
+					resource.TestCheckResourceAttrSet("data.tabular_role.test", "children.#"),
+					resource.TestCheckResourceAttrSet("data.tabular_role.test", "parents.#"),
 				),
 			},
 		},
@@ -23,6 +28,32 @@ func TestAccRoleDataSource(t *testing.T) {
 
 const testAccRoleDataSourceConfig = `
 data "tabular_role" "test" {
-  name = "Terraform"
+  name            = "Terraform"
+  include_parents = true
 }
 `
+
+func TestPartitionRoleMembers(t *testing.T) {
+	roleMembers := []tabularv2.MemberEntry{
+		{Id: tabularv2.PtrString("1"), Email: tabularv2.PtrString("admin@example.com"), WithAdmin: tabularv2.PtrBool(true)},
+		{Id: tabularv2.PtrString("2"), Email: tabularv2.PtrString("user@example.com")},
+		{Id: tabularv2.PtrString("3"), WithAdmin: tabularv2.PtrBool(true)},
+	}
+	serviceAccounts := []tabular.Credential{{Id: "c1", Key: "t-abc", MemberId: "3", Name: "etl"}}
+
+	adminMembers, members, serviceAccountKeys := partitionRoleMembers(roleMembers, serviceAccounts)
+	assert.Equal(t, []string{"admin@example.com"}, adminMembers)
+	assert.Equal(t, []string{"user@example.com"}, members)
+	assert.Equal(t, []string{"t-abc"}, serviceAccountKeys)
+}
+
+func TestParentRoleNames(t *testing.T) {
+	roles := []tabular.Role{
+		{Name: "engineering", Children: []tabular.Role{{Name: "analysts"}, {Name: "data"}}},
+		{Name: "data", Children: []tabular.Role{{Name: "analysts"}}},
+		{Name: "analysts"},
+	}
+
+	assert.Equal(t, []string{"engineering", "data"}, parentRoleNames(roles, "analysts"))
+	assert.Empty(t, parentRoleNames(roles, "engineering"))
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -240,24 +241,12 @@ func (r *roleDatabaseGrantsResource) Read(ctx context.Context, req resource.Read
 	warehouseId := state.WarehouseId.ValueString()
 	databaseId := state.DatabaseId.ValueString()
 	roleId := state.RoleId.ValueString()
-	retryFunc := util.RetryResourceResponse[*tabular.GetRoleDatabaseGrantsResponse]
-	databaseGrants, _, err := retryFunc(r.client.V2.DefaultAPI.ListDatabaseRoleGrantsForRole(ctx, *r.client.OrganizationId, warehouseId, databaseId, roleId).Execute)
+	privileges, privilegesWithGrant, err := r.readGrants(ctx, warehouseId, databaseId, roleId)
 	if err != nil {
 		resp.Diagnostics.AddError("Error fetching grants for role", err.Error())
 		return
 	}
 
-	var privileges []string
-	var privilegesWithGrant []string
-
-	for _, grant := range databaseGrants.Authorizations {
-		if *grant.WithGrant {
-			privilegesWithGrant = append(privilegesWithGrant, *grant.Privilege)
-		} else {
-			privileges = append(privileges, *grant.Privilege)
-		}
-	}
-
 	state.Privileges, diags = types.SetValueFrom(ctx, types.StringType, privileges)
 	resp.Diagnostics.Append(diags...)
 	state.PrivilegesWithGrant, diags = types.SetValueFrom(ctx, types.StringType, privilegesWithGrant)
@@ -314,6 +303,7 @@ func (r *roleDatabaseGrantsResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	var diags diag.Diagnostics
 	warehouseId := plan.WarehouseId.ValueString()
 	databaseId := plan.DatabaseId.ValueString()
 	roleId := plan.RoleId.ValueString()
@@ -326,6 +316,12 @@ func (r *roleDatabaseGrantsResource) Update(ctx context.Context, req resource.Up
 	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &statePlanPrivileges, false)...)
 	resp.Diagnostics.Append(state.PrivilegesWithGrant.ElementsAs(ctx, &statePlanPrivilegesWithGrant, false)...)
 
+	// Tracks the grants applied so far, so state only ever records what the role has actually been given
+	applied := plan
+	applied.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", warehouseId, databaseId, roleId))
+	applied.Privileges = state.Privileges
+	applied.PrivilegesWithGrant = state.PrivilegesWithGrant
+
 	// Remove privileges
 	privilegesToRemove := internal.Difference(statePlanPrivileges, planPrivileges)
 	privilegesToRemoveWithGrant := internal.Difference(statePlanPrivilegesWithGrant, planPrivilegesWithGrant)
@@ -339,11 +335,19 @@ func (r *roleDatabaseGrantsResource) Update(ctx context.Context, req resource.Up
 			Execute()
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to revoke grant", "Unable to revoke grant"+err.Error())
+			r.refreshState(ctx, applied, resp)
 			return
 		}
-	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		applied.Privileges, diags = types.SetValueFrom(ctx, types.StringType, internal.Difference(statePlanPrivileges, privilegesToRemove))
+		resp.Diagnostics.Append(diags...)
+		applied.PrivilegesWithGrant, diags = types.SetValueFrom(ctx, types.StringType, internal.Difference(statePlanPrivilegesWithGrant, privilegesToRemoveWithGrant))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, applied)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Add privileges
 	privilegesToAdd := internal.Difference(planPrivileges, statePlanPrivileges)
@@ -358,6 +362,7 @@ func (r *roleDatabaseGrantsResource) Update(ctx context.Context, req resource.Up
 			Execute()
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to create grant", "Unable to create grant"+err.Error())
+			r.refreshState(ctx, applied, resp)
 			return
 		}
 	}
@@ -403,6 +408,41 @@ func (r *roleDatabaseGrantsResource) Delete(ctx context.Context, req resource.De
 	resp.State.RemoveResource(ctx)
 }
 
+// readGrants fetches the privileges the role currently holds on the database, split by grant option.
+func (r *roleDatabaseGrantsResource) readGrants(ctx context.Context, warehouseId, databaseId, roleId string) (privileges, privilegesWithGrant []string, err error) {
+	retryFunc := util.RetryResourceResponse[*tabular.GetRoleDatabaseGrantsResponse]
+	databaseGrants, _, err := retryFunc(r.client.V2.DefaultAPI.ListDatabaseRoleGrantsForRole(ctx, *r.client.OrganizationId, warehouseId, databaseId, roleId).Execute)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, grant := range databaseGrants.Authorizations {
+		if *grant.WithGrant {
+			privilegesWithGrant = append(privilegesWithGrant, *grant.Privilege)
+		} else {
+			privileges = append(privileges, *grant.Privilege)
+		}
+	}
+	return privileges, privilegesWithGrant, nil
+}
+
+// refreshState is used when an update fails part way through. It persists the grants the role actually holds so the
+// next plan shows any remaining drift. If they can't be fetched, the applied state passed in is kept instead.
+func (r *roleDatabaseGrantsResource) refreshState(ctx context.Context, state roleDatabaseGrantsModel, resp *resource.UpdateResponse) {
+	privileges, privilegesWithGrant, err := r.readGrants(ctx, state.WarehouseId.ValueString(), state.DatabaseId.ValueString(), state.RoleId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to refresh grants after failed update", err.Error())
+	} else {
+		var diags diag.Diagnostics
+		state.Privileges, diags = types.SetValueFrom(ctx, types.StringType, privileges)
+		resp.Diagnostics.Append(diags...)
+		state.PrivilegesWithGrant, diags = types.SetValueFrom(ctx, types.StringType, privilegesWithGrant)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
 func databasePrivilegeRequest(privileges []string, withGrant bool, roleId string) []tabular.RoleDatabaseGrantRequest {
 	var roleDatabaseGrantRequest []tabular.RoleDatabaseGrantRequest
 	for _, privilege := range privileges {
@@ -236,3 +236,30 @@ resource "tabular_role_database_grants" "test" {
 	
 `, bucketName, roleArn, warehouseName, databaseName, tabularRole)
 }
+
+func TestAccRoleDatabaseGrantsUpdate(t *testing.T) {
+	testId := fmt.Sprintf("tf-acc-test-%d", rand.Intn(100))
+	bucketName := os.Getenv("TABULAR_AWS_S3_BUCKET")
+	roleArn := os.Getenv("TABULAR_AWS_IAM_ROLE_ARN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { accPreCheck(t) },
+		ProtoV6ProviderFactories: accProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleDatabaseGrantsConfigWithoutGrants(bucketName, roleArn, testId, testId, testId, "FUTURE_DROP_TABLE"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tabular_role_database_grants.test", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("tabular_role_database_grants.test", "privileges.0", "FUTURE_DROP_TABLE"),
+				),
+			},
+			{
+				Config: testAccRoleDatabaseGrantsConfigWithoutGrants(bucketName, roleArn, testId, testId, testId, "LIST_TABLES"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tabular_role_database_grants.test", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("tabular_role_database_grants.test", "privileges.0", "LIST_TABLES"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"golang.org/x/exp/slices"
+	"sort"
+	"strings"
+)
+
+var _ datasource.DataSource = &RoleEffectivePrivilegesDataSource{}
+var _ datasource.DataSourceWithConfigure = &RoleEffectivePrivilegesDataSource{}
+
+func NewRoleEffectivePrivilegesDataSource() datasource.DataSource {
+	return &RoleEffectivePrivilegesDataSource{}
+}
+
+type RoleEffectivePrivilegesDataSource struct {
+	client *util.Client
+}
+
+type RoleEffectivePrivilegesDataSourceModel struct {
+	RoleName       types.String              `tfsdk:"role_name"`
+	WarehouseId    types.String              `tfsdk:"warehouse_id"`
+	Databases      types.Set                 `tfsdk:"databases"`
+	InheritedRoles types.List                `tfsdk:"inherited_roles"`
+	Privileges     []EffectivePrivilegeModel `tfsdk:"privileges"`
+}
+
+type EffectivePrivilegeModel struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	Database     types.String `tfsdk:"database"`
+	Privilege    types.String `tfsdk:"privilege"`
+	WithGrant    types.Bool   `tfsdk:"with_grant"`
+	Future       types.Bool   `tfsdk:"future"`
+	GrantedTo    types.String `tfsdk:"granted_to"`
+	Path         types.List   `tfsdk:"path"`
+}
+
+const (
+	privilegeResourceWarehouse = "WAREHOUSE"
+	privilegeResourceDatabase  = "DATABASE"
+)
+
+func (d *RoleEffectivePrivilegesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_effective_privileges"
+}
+
+func (d *RoleEffectivePrivilegesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Warehouse and database privileges a role holds in a warehouse, both granted to it directly " +
+			"and inherited from its child roles",
+
+		Attributes: map[string]schema.Attribute{
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Role Name",
+				Required:            true,
+			},
+			"warehouse_id": schema.StringAttribute{
+				MarkdownDescription: "Warehouse ID",
+				Required:            true,
+			},
+			"databases": schema.SetAttribute{
+				MarkdownDescription: "Database names to report privileges for. Defaults to every database in the warehouse.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"inherited_roles": schema.ListAttribute{
+				MarkdownDescription: "Names of the roles the role inherits privileges from, nearest first",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"privileges": schema.ListNestedAttribute{
+				MarkdownDescription: "Every privilege the role holds, once for each role it's granted to",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "`WAREHOUSE` or `DATABASE`",
+							Computed:            true,
+						},
+						"database": schema.StringAttribute{
+							MarkdownDescription: "Database Name, null for warehouse privileges",
+							Computed:            true,
+						},
+						"privilege": schema.StringAttribute{
+							MarkdownDescription: "Privilege",
+							Computed:            true,
+						},
+						"with_grant": schema.BoolAttribute{
+							MarkdownDescription: "Whether the privilege can be granted to other roles",
+							Computed:            true,
+						},
+						"future": schema.BoolAttribute{
+							MarkdownDescription: "Whether this is a `FUTURE_*` privilege, applying to tables created later",
+							Computed:            true,
+						},
+						"granted_to": schema.StringAttribute{
+							MarkdownDescription: "Role the privilege is granted to",
+							Computed:            true,
+						},
+						"path": schema.ListAttribute{
+							MarkdownDescription: "Role names from `role_name` down to `granted_to`",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RoleEffectivePrivilegesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RoleEffectivePrivilegesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoleEffectivePrivilegesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var databases []string
+	if !data.Databases.IsNull() {
+		databases = make([]string, 0)
+		resp.Diagnostics.Append(data.Databases.ElementsAs(ctx, &databases, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	warehouseDatabases, err := readWarehouseDatabases(ctx, d.client, data.WarehouseId.ValueString(), databases)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching databases", err.Error())
+		return
+	}
+
+	roles, err := expandRoleHierarchy(data.RoleName.ValueString(), d.client.V1.GetRole)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", err.Error())
+		return
+	}
+
+	grants := make(map[string]warehouseRoleGrants, len(roles))
+	for _, role := range roles {
+		grants[role.Id], err = warehouseDatabases.readRoleGrants(ctx, d.client, role.Id)
+		if err != nil {
+			resp.Diagnostics.AddError("Error fetching grants", "Could not fetch grants for role "+role.Name+": "+err.Error())
+			return
+		}
+	}
+	data.Privileges = effectivePrivileges(ctx, roles, warehouseDatabases.names, grants)
+
+	inheritedRoles := internal.Map(roles[1:], func(r inheritedRole) string { return r.Name })
+	var diags diag.Diagnostics
+	data.InheritedRoles, diags = types.ListValueFrom(ctx, types.StringType, inheritedRoles)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// effectivePrivileges lists the grants of every role in roles, keyed by role id, on the warehouse and databases
+func effectivePrivileges(ctx context.Context, roles []inheritedRole, databases []string, grants map[string]warehouseRoleGrants) []EffectivePrivilegeModel {
+	privileges := make([]EffectivePrivilegeModel, 0)
+	for _, role := range roles {
+		for _, grant := range grants[role.Id].Warehouse {
+			privileges = append(privileges, newEffectivePrivilege(ctx, role, privilegeResourceWarehouse, "", grant.GetPrivilege(), grant.GetWithGrant()))
+		}
+		for _, database := range databases {
+			for _, grant := range grants[role.Id].Databases[database] {
+				privileges = append(privileges, newEffectivePrivilege(ctx, role, privilegeResourceDatabase, database, grant.GetPrivilege(), grant.GetWithGrant()))
+			}
+		}
+	}
+	sortEffectivePrivileges(privileges)
+	return privileges
+}
+
+func newEffectivePrivilege(ctx context.Context, role inheritedRole, resourceType, database, privilege string, withGrant bool) EffectivePrivilegeModel {
+	path, _ := types.ListValueFrom(ctx, types.StringType, role.Path)
+	effectivePrivilege := EffectivePrivilegeModel{
+		ResourceType: types.StringValue(resourceType),
+		Database:     types.StringNull(),
+		Privilege:    types.StringValue(privilege),
+		WithGrant:    types.BoolValue(withGrant),
+		Future:       types.BoolValue(strings.HasPrefix(privilege, "FUTURE_")),
+		GrantedTo:    types.StringValue(role.Name),
+		Path:         path,
+	}
+	if database != "" {
+		effectivePrivilege.Database = types.StringValue(database)
+	}
+	return effectivePrivilege
+}
+
+// sortEffectivePrivileges orders warehouse privileges before database privileges, then by database and privilege,
+// with direct grants ahead of inherited ones
+func sortEffectivePrivileges(privileges []EffectivePrivilegeModel) {
+	sort.SliceStable(privileges, func(i, j int) bool {
+		a, b := privileges[i], privileges[j]
+		if a.ResourceType.ValueString() != b.ResourceType.ValueString() {
+			return a.ResourceType.ValueString() == privilegeResourceWarehouse
+		}
+		if a.Database.ValueString() != b.Database.ValueString() {
+			return a.Database.ValueString() < b.Database.ValueString()
+		}
+		if a.Privilege.ValueString() != b.Privilege.ValueString() {
+			return a.Privilege.ValueString() < b.Privilege.ValueString()
+		}
+		return len(a.Path.Elements()) < len(b.Path.Elements())
+	})
+}
+
+// warehouseDatabases are the databases of a warehouse that grants are read on, in name order
+type warehouseDatabases struct {
+	warehouseId string
+	names       []string
+	ids         map[string]string
+}
+
+// warehouseRoleGrants are a role's grants on a warehouse and on each of its databases, by database name
+type warehouseRoleGrants struct {
+	Warehouse []tabular.WarehouseAuthorization
+	Databases map[string][]tabular.DatabaseAuthorization
+}
+
+// readWarehouseDatabases looks up the ids of the named databases, or of every database in the warehouse when names is
+// nil, so grants on them can be read for any number of roles
+func readWarehouseDatabases(ctx context.Context, client *util.Client, warehouseId string, names []string) (*warehouseDatabases, error) {
+	if names == nil {
+		var err error
+		names, err = client.V1.ListDatabases(warehouseId)
+		if err != nil {
+			return nil, fmt.Errorf("could not list databases in warehouse %s: %w", warehouseId, err)
+		}
+	}
+	names = slices.Clone(names)
+	sort.Strings(names)
+
+	ids := make(map[string]string, len(names))
+	for _, database := range names {
+		retryFunc := util.RetryResourceResponse[*tabular.GetDatabaseResponse]
+		databaseResp, _, err := retryFunc(client.V2.DefaultAPI.GetDatabase(ctx, *client.OrganizationId, warehouseId, database).Execute)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch database %s: %w", database, err)
+		}
+		ids[database] = databaseResp.GetId()
+	}
+	return &warehouseDatabases{warehouseId: warehouseId, names: names, ids: ids}, nil
+}
+
+// readRoleGrants fetches the role's grants on the warehouse and each of its databases
+func (w *warehouseDatabases) readRoleGrants(ctx context.Context, client *util.Client, roleId string) (warehouseRoleGrants, error) {
+	grants := warehouseRoleGrants{Databases: make(map[string][]tabular.DatabaseAuthorization, len(w.names))}
+	retryFunc := util.RetryResourceResponse[*tabular.GetRoleWarehouseGrantsResponse]
+	warehouseGrants, _, err := retryFunc(client.V2.DefaultAPI.ListWarehouseRoleGrantsForRole(ctx, *client.OrganizationId, w.warehouseId, roleId).Execute)
+	if err != nil {
+		return grants, fmt.Errorf("could not fetch grants on warehouse %s: %w", w.warehouseId, err)
+	}
+	grants.Warehouse = warehouseGrants.Authorizations
+
+	for _, database := range w.names {
+		retryFunc := util.RetryResourceResponse[*tabular.GetRoleDatabaseGrantsResponse]
+		databaseGrants, _, err := retryFunc(client.V2.DefaultAPI.ListDatabaseRoleGrantsForRole(ctx, *client.OrganizationId, w.warehouseId, w.ids[database], roleId).Execute)
+		if err != nil {
+			return grants, fmt.Errorf("could not fetch grants on %s: %w", database, err)
+		}
+		grants.Databases[database] = databaseGrants.Authorizations
+	}
+	return grants, nil
+}
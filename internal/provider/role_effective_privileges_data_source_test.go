@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func testWarehouseGrant(privilege string, withGrant bool) tabularv2.WarehouseAuthorization {
+	return tabularv2.WarehouseAuthorization{Privilege: tabularv2.PtrString(privilege), WithGrant: tabularv2.PtrBool(withGrant)}
+}
+
+func testDatabaseGrant(privilege string, withGrant bool) tabularv2.DatabaseAuthorization {
+	return tabularv2.DatabaseAuthorization{Privilege: tabularv2.PtrString(privilege), WithGrant: tabularv2.PtrBool(withGrant)}
+}
+
+// describeEffectivePrivilege renders a privilege as "resource privilege granted_to (path)" with markers for with_grant
+// and future privileges
+func describeEffectivePrivilege(p EffectivePrivilegeModel) string {
+	resource := p.ResourceType.ValueString()
+	if !p.Database.IsNull() {
+		resource = p.Database.ValueString()
+	}
+	path := internal.Map(p.Path.Elements(), func(v attr.Value) string { return v.(types.String).ValueString() })
+	description := fmt.Sprintf("%s %s %s (%s)", resource, p.Privilege.ValueString(), p.GrantedTo.ValueString(), strings.Join(path, " > "))
+	if p.WithGrant.ValueBool() {
+		description += " with grant"
+	}
+	if p.Future.ValueBool() {
+		description += " future"
+	}
+	return description
+}
+
+func TestEffectivePrivileges(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []tabular.Role
+		databases []string
+		grants    map[string]warehouseRoleGrants
+		expected  []string
+	}{
+		{
+			name: "inherits through a chain of children",
+			roles: []tabular.Role{
+				{Id: "1", Name: "engineering", Children: []tabular.Role{{Id: "2", Name: "data"}}},
+				{Id: "2", Name: "data", Children: []tabular.Role{{Id: "3", Name: "readers"}}},
+				{Id: "3", Name: "readers"},
+			},
+			databases: []string{"marketing", "sales"},
+			grants: map[string]warehouseRoleGrants{
+				"1": {
+					Databases: map[string][]tabularv2.DatabaseAuthorization{
+						"sales": {testDatabaseGrant("LIST_TABLES", true)},
+					},
+				},
+				"2": {
+					Warehouse: []tabularv2.WarehouseAuthorization{testWarehouseGrant("CREATE_DATABASE", false)},
+					Databases: map[string][]tabularv2.DatabaseAuthorization{
+						"sales": {testDatabaseGrant("FUTURE_SELECT", false)},
+					},
+				},
+				"3": {
+					Warehouse: []tabularv2.WarehouseAuthorization{testWarehouseGrant("CREATE_DATABASE", false)},
+					Databases: map[string][]tabularv2.DatabaseAuthorization{
+						"marketing": {testDatabaseGrant("LIST_TABLES", false)},
+						"sales":     {testDatabaseGrant("LIST_TABLES", false)},
+					},
+				},
+			},
+			expected: []string{
+				"WAREHOUSE CREATE_DATABASE data (engineering > data)",
+				"WAREHOUSE CREATE_DATABASE readers (engineering > data > readers)",
+				"marketing LIST_TABLES readers (engineering > data > readers)",
+				"sales FUTURE_SELECT data (engineering > data) future",
+				"sales LIST_TABLES engineering (engineering) with grant",
+				"sales LIST_TABLES readers (engineering > data > readers)",
+			},
+		},
+		{
+			name: "reports a child shared by several roles once",
+			roles: []tabular.Role{
+				{Id: "1", Name: "engineering", Children: []tabular.Role{{Id: "2", Name: "data"}, {Id: "3", Name: "ml"}}},
+				{Id: "2", Name: "data", Children: []tabular.Role{{Id: "4", Name: "readers"}}},
+				{Id: "3", Name: "ml", Children: []tabular.Role{{Id: "4", Name: "readers"}}},
+				{Id: "4", Name: "readers"},
+			},
+			databases: []string{"sales"},
+			grants: map[string]warehouseRoleGrants{
+				"4": {
+					Databases: map[string][]tabularv2.DatabaseAuthorization{
+						"sales": {testDatabaseGrant("LIST_TABLES", false)},
+					},
+				},
+			},
+			expected: []string{
+				"sales LIST_TABLES readers (engineering > data > readers)",
+			},
+		},
+		{
+			name:      "without grants",
+			roles:     []tabular.Role{{Id: "1", Name: "engineering"}},
+			databases: []string{"sales"},
+			grants:    map[string]warehouseRoleGrants{},
+			expected:  []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roles, err := expandRoleHierarchy(test.roles[0].Name, testRoleGetter(test.roles...))
+			assert.NoError(t, err)
+
+			privileges := effectivePrivileges(context.Background(), roles, test.databases, test.grants)
+			assert.Equal(t, test.expected, internal.Map(privileges, describeEffectivePrivilege))
+		})
+	}
+}
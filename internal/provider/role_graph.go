@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	"sort"
+	"strings"
+)
+
+// inheritedRole is a role reached while walking down the hierarchy from another role. Path holds the role names
+// from the starting role down to this one.
+type inheritedRole struct {
+	Id   string
+	Name string
+	Path []string
+}
+
+// expandRoleHierarchy returns roleName followed by every role it inherits through its children, breadth first, so
+// each role is reported with the shortest path leading to it.
+func expandRoleHierarchy(roleName string, getRole func(string) (*tabular.Role, error)) ([]inheritedRole, error) {
+	role, err := getRole(roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, fmt.Errorf("could not find role %s", roleName)
+	}
+
+	roles := []inheritedRole{{Id: role.Id, Name: role.Name, Path: []string{role.Name}}}
+	visited := map[string]bool{role.Name: true}
+	for i := 0; i < len(roles); i++ {
+		current := roles[i]
+		if i > 0 {
+			role, err = getRole(current.Name)
+			if err != nil {
+				return nil, err
+			}
+			// A child deleted while walking has nothing left to inherit
+			if role == nil {
+				continue
+			}
+		}
+
+		for _, child := range role.Children {
+			if visited[child.Name] {
+				continue
+			}
+			visited[child.Name] = true
+			roles = append(roles, inheritedRole{
+				Id:   child.Id,
+				Name: child.Name,
+				Path: append(slices.Clone(current.Path), child.Name),
+			})
+		}
+	}
+	return roles, nil
+}
+
+// roleGraphNode is a role with everything attached to it in the role graph
+type roleGraphNode struct {
+	Id              string   `json:"id"`
+	Children        []string `json:"children"`
+	AdminMembers    []string `json:"admin_members"`
+	Members         []string `json:"members"`
+	ServiceAccounts []string `json:"service_accounts"`
+	Grants          []string `json:"grants,omitempty"`
+}
+
+// roleGraph maps role names to their nodes
+type roleGraph map[string]*roleGraphNode
+
+func (g roleGraph) roleNames() []string {
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// toJSON renders the graph as an adjacency list keyed by role name
+func (g roleGraph) toJSON() (string, error) {
+	out, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// toDOT renders the graph for Graphviz. Roles point at their children, and members and service accounts point at
+// the roles they belong to.
+func (g roleGraph) toDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph roles {\n  rankdir=LR;\n")
+	for _, name := range g.roleNames() {
+		node := g[name]
+		// Grants go on their own lines under the role name
+		label := `"` + strings.Join(internal.Map(append([]string{name}, node.Grants...), dotEscape), `\n`) + `"`
+		fmt.Fprintf(&b, "  %s [label=%s, shape=box];\n", dotQuote("role:"+name), label)
+		for _, child := range node.Children {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote("role:"+name), dotQuote("role:"+child))
+		}
+		for _, member := range node.AdminMembers {
+			fmt.Fprintf(&b, "  %s [label=%s, shape=ellipse];\n", dotQuote("member:"+member), dotQuote(member))
+			fmt.Fprintf(&b, "  %s -> %s [label=\"admin\"];\n", dotQuote("member:"+member), dotQuote("role:"+name))
+		}
+		for _, member := range node.Members {
+			fmt.Fprintf(&b, "  %s [label=%s, shape=ellipse];\n", dotQuote("member:"+member), dotQuote(member))
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote("member:"+member), dotQuote("role:"+name))
+		}
+		for _, serviceAccount := range node.ServiceAccounts {
+			fmt.Fprintf(&b, "  %s [label=%s, shape=hexagon];\n", dotQuote("service:"+serviceAccount), dotQuote(serviceAccount))
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote("service:"+serviceAccount), dotQuote("role:"+name))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// toMermaid renders the graph as a Mermaid flowchart, with the same edges as toDOT
+func (g roleGraph) toMermaid() string {
+	ids := make(map[string]string)
+	declared := make(map[string]bool)
+	var b strings.Builder
+	// Mermaid ids can't contain most punctuation, so every node gets a generated one
+	node := func(key, label, open, close string) string {
+		id, ok := ids[key]
+		if !ok {
+			id = fmt.Sprintf("n%d", len(ids))
+			ids[key] = id
+		}
+		if !declared[key] && label != "" {
+			declared[key] = true
+			fmt.Fprintf(&b, "  %s%s%s%s\n", id, open, mermaidQuote(label), close)
+		}
+		return id
+	}
+
+	b.WriteString("graph LR\n")
+	for _, name := range g.roleNames() {
+		node("role:"+name, strings.Join(append([]string{name}, g[name].Grants...), "<br/>"), "[", "]")
+	}
+	for _, name := range g.roleNames() {
+		roleNode := g[name]
+		roleId := node("role:"+name, "", "", "")
+		for _, child := range roleNode.Children {
+			fmt.Fprintf(&b, "  %s --> %s\n", roleId, node("role:"+child, child, "[", "]"))
+		}
+		for _, member := range roleNode.AdminMembers {
+			fmt.Fprintf(&b, "  %s -- admin --> %s\n", node("member:"+member, member, "([", "])"), roleId)
+		}
+		for _, member := range roleNode.Members {
+			fmt.Fprintf(&b, "  %s --> %s\n", node("member:"+member, member, "([", "])"), roleId)
+		}
+		for _, serviceAccount := range roleNode.ServiceAccounts {
+			fmt.Fprintf(&b, "  %s --> %s\n", node("service:"+serviceAccount, serviceAccount, "{{", "}}"), roleId)
+		}
+	}
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+func dotEscape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`)
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
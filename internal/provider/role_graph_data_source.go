@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"sort"
+	"strings"
+)
+
+var _ datasource.DataSource = &RoleGraphDataSource{}
+var _ datasource.DataSourceWithConfigure = &RoleGraphDataSource{}
+
+func NewRoleGraphDataSource() datasource.DataSource {
+	return &RoleGraphDataSource{}
+}
+
+type RoleGraphDataSource struct {
+	client *util.Client
+}
+
+type RoleGraphDataSourceModel struct {
+	Roles        types.Set    `tfsdk:"roles"`
+	WarehouseIds types.Set    `tfsdk:"warehouse_ids"`
+	Dot          types.String `tfsdk:"dot"`
+	Mermaid      types.String `tfsdk:"mermaid"`
+	Json         types.String `tfsdk:"json"`
+}
+
+func (d *RoleGraphDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_graph"
+}
+
+func (d *RoleGraphDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders roles, their child roles, members and service accounts as a graph, " +
+			"for documenting the access model",
+
+		Attributes: map[string]schema.Attribute{
+			"roles": schema.SetAttribute{
+				MarkdownDescription: "Names of the roles to start from. Every child role below them is included too. " +
+					"Defaults to every role in the org.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"warehouse_ids": schema.SetAttribute{
+				MarkdownDescription: "Warehouse IDs whose warehouse and database grants are added to each role",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"dot": schema.StringAttribute{
+				MarkdownDescription: "Graphviz DOT rendering of the graph",
+				Computed:            true,
+			},
+			"mermaid": schema.StringAttribute{
+				MarkdownDescription: "Mermaid flowchart rendering of the graph",
+				Computed:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "JSON adjacency list, keyed by role name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoleGraphDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RoleGraphDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoleGraphDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var roleNames, warehouseIds []string
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roleNames, true)...)
+	resp.Diagnostics.Append(data.WarehouseIds.ElementsAs(ctx, &warehouseIds, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Roles.IsNull() {
+		roles, err := d.client.V1.ListRoles()
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing roles", err.Error())
+			return
+		}
+		roleNames = internal.Map(roles, func(r tabular.Role) string { return r.Name })
+	}
+
+	serviceAccounts, err := d.client.V1.GetServiceAccounts()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+		return
+	}
+	serviceAccountNames := make(map[string]string, len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		name := serviceAccount.Name
+		if name == "" {
+			name = serviceAccount.Key
+		}
+		serviceAccountNames[serviceAccount.MemberId] = name
+	}
+
+	// Walking from several roles reaches shared children more than once, so each role is only fetched once
+	fetched := make(map[string]*tabular.Role)
+	getRole := func(roleName string) (*tabular.Role, error) {
+		if role, ok := fetched[roleName]; ok {
+			return role, nil
+		}
+		role, err := d.client.V1.GetRole(roleName)
+		if err != nil {
+			return nil, err
+		}
+		fetched[roleName] = role
+		return role, nil
+	}
+
+	graph := make(roleGraph)
+	for _, roleName := range roleNames {
+		roles, err := expandRoleHierarchy(roleName, getRole)
+		if err != nil {
+			resp.Diagnostics.AddError("Error fetching role", err.Error())
+			return
+		}
+		for _, r := range roles {
+			if role := fetched[r.Name]; role != nil && graph[r.Name] == nil {
+				graph[r.Name] = newRoleGraphNode(role, serviceAccountNames)
+			}
+		}
+	}
+
+	for _, warehouseId := range warehouseIds {
+		d.addGrants(ctx, graph, warehouseId, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.Dot = types.StringValue(graph.toDOT())
+	data.Mermaid = types.StringValue(graph.toMermaid())
+	graphJson, err := graph.toJSON()
+	if err != nil {
+		resp.Diagnostics.AddError("Error rendering role graph", err.Error())
+		return
+	}
+	data.Json = types.StringValue(graphJson)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// addGrants annotates every role in the graph with its grants on the warehouse and the warehouse's databases
+func (d *RoleGraphDataSource) addGrants(ctx context.Context, graph roleGraph, warehouseId string, diags *diag.Diagnostics) {
+	warehouse, _, err := util.RetryResourceResponse[*tabularv2.GetWarehouseResponse](
+		d.client.V2.DefaultAPI.GetWarehouse(ctx, *d.client.OrganizationId, warehouseId).Execute,
+	)
+	if err != nil {
+		diags.AddError("Error fetching warehouse", "Could not fetch warehouse "+warehouseId+": "+err.Error())
+		return
+	}
+	warehouseName := warehouse.GetName()
+
+	warehouseDatabases, err := readWarehouseDatabases(ctx, d.client, warehouseId, nil)
+	if err != nil {
+		diags.AddError("Error fetching databases", err.Error())
+		return
+	}
+
+	for _, roleName := range graph.roleNames() {
+		node := graph[roleName]
+		grants, err := warehouseDatabases.readRoleGrants(ctx, d.client, node.Id)
+		if err != nil {
+			diags.AddError("Error fetching grants", "Could not fetch grants for role "+roleName+": "+err.Error())
+			return
+		}
+		node.addGrants(warehouseName, internal.Map(grants.Warehouse, func(a tabularv2.WarehouseAuthorization) string {
+			return grantLabel(a.GetPrivilege(), a.GetWithGrant())
+		}))
+		for _, database := range warehouseDatabases.names {
+			node.addGrants(warehouseName+"."+database, internal.Map(grants.Databases[database], func(a tabularv2.DatabaseAuthorization) string {
+				return grantLabel(a.GetPrivilege(), a.GetWithGrant())
+			}))
+		}
+	}
+}
+
+func newRoleGraphNode(role *tabular.Role, serviceAccountNames map[string]string) *roleGraphNode {
+	node := &roleGraphNode{
+		Id:              role.Id,
+		Children:        internal.Map(role.Children, func(c tabular.Role) string { return c.Name }),
+		AdminMembers:    make([]string, 0),
+		Members:         make([]string, 0),
+		ServiceAccounts: make([]string, 0),
+	}
+	for _, member := range role.Members {
+		if name, ok := serviceAccountNames[member.Id]; ok {
+			node.ServiceAccounts = append(node.ServiceAccounts, name)
+		} else if member.WithAdmin {
+			node.AdminMembers = append(node.AdminMembers, member.Email)
+		} else {
+			node.Members = append(node.Members, member.Email)
+		}
+	}
+	sort.Strings(node.Children)
+	sort.Strings(node.AdminMembers)
+	sort.Strings(node.Members)
+	sort.Strings(node.ServiceAccounts)
+	return node
+}
+
+// addGrants records the privileges held on a resource as a single "resource: PRIVILEGE, ..." line
+func (n *roleGraphNode) addGrants(resource string, privileges []string) {
+	if len(privileges) == 0 {
+		return
+	}
+	sort.Strings(privileges)
+	n.Grants = append(n.Grants, resource+": "+strings.Join(privileges, ", "))
+}
+
+func grantLabel(privilege string, withGrant bool) string {
+	if withGrant {
+		return privilege + " (with grant)"
+	}
+	return privilege
+}
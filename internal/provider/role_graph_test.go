@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func testRoleGetter(roles ...tabular.Role) func(string) (*tabular.Role, error) {
+	return func(roleName string) (*tabular.Role, error) {
+		for i := range roles {
+			if roles[i].Name == roleName {
+				return &roles[i], nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestExpandRoleHierarchy(t *testing.T) {
+	getRole := testRoleGetter(
+		tabular.Role{Id: "1", Name: "engineering", Children: []tabular.Role{{Id: "2", Name: "data"}, {Id: "3", Name: "ml"}}},
+		tabular.Role{Id: "2", Name: "data", Children: []tabular.Role{{Id: "4", Name: "readers"}}},
+		tabular.Role{Id: "3", Name: "ml", Children: []tabular.Role{{Id: "4", Name: "readers"}, {Id: "1", Name: "engineering"}}},
+		tabular.Role{Id: "4", Name: "readers"},
+	)
+
+	roles, err := expandRoleHierarchy("engineering", getRole)
+	assert.NoError(t, err)
+	assert.Equal(t, []inheritedRole{
+		{Id: "1", Name: "engineering", Path: []string{"engineering"}},
+		{Id: "2", Name: "data", Path: []string{"engineering", "data"}},
+		{Id: "3", Name: "ml", Path: []string{"engineering", "ml"}},
+		{Id: "4", Name: "readers", Path: []string{"engineering", "data", "readers"}},
+	}, roles)
+
+	_, err = expandRoleHierarchy("missing", getRole)
+	assert.Error(t, err)
+}
+
+func TestRoleGraphRendering(t *testing.T) {
+	graph := roleGraph{
+		"engineering": newRoleGraphNode(&tabular.Role{
+			Id:       "1",
+			Name:     "engineering",
+			Children: []tabular.Role{{Id: "2", Name: "readers"}},
+			Members: []tabular.Member{
+				{Id: "m1", Email: "lead@example.com", WithAdmin: true},
+				{Id: "m2", Email: "dev@example.com"},
+				{Id: "m3", Email: ""},
+			},
+		}, map[string]string{"m3": "etl"}),
+		"readers": newRoleGraphNode(&tabular.Role{Id: "2", Name: "readers"}, nil),
+	}
+	graph["readers"].addGrants("prod.sales", []string{grantLabel("SELECT", false), grantLabel("DESCRIBE", true)})
+
+	assert.Equal(t, `digraph roles {
+  rankdir=LR;
+  "role:engineering" [label="engineering", shape=box];
+  "role:engineering" -> "role:readers";
+  "member:lead@example.com" [label="lead@example.com", shape=ellipse];
+  "member:lead@example.com" -> "role:engineering" [label="admin"];
+  "member:dev@example.com" [label="dev@example.com", shape=ellipse];
+  "member:dev@example.com" -> "role:engineering";
+  "service:etl" [label="etl", shape=hexagon];
+  "service:etl" -> "role:engineering";
+  "role:readers" [label="readers\nprod.sales: DESCRIBE (with grant), SELECT", shape=box];
+}
+`, graph.toDOT())
+
+	assert.Equal(t, `graph LR
+  n0["engineering"]
+  n1["readers<br/>prod.sales: DESCRIBE (with grant), SELECT"]
+  n0 --> n1
+  n2(["lead@example.com"])
+  n2 -- admin --> n0
+  n3(["dev@example.com"])
+  n3 --> n0
+  n4{{"etl"}}
+  n4 --> n0
+`, graph.toMermaid())
+
+	graphJson, err := graph.toJSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+  "engineering": {"id": "1", "children": ["readers"], "admin_members": ["lead@example.com"], "members": ["dev@example.com"], "service_accounts": ["etl"]},
+  "readers": {"id": "2", "children": [], "admin_members": [], "members": [], "service_accounts": [], "grants": ["prod.sales: DESCRIBE (with grant), SELECT"]}
+}`, graphJson)
+}
+
+func TestDotQuote(t *testing.T) {
+	assert.Equal(t, `"say \"hi\" \\o/"`, dotQuote(`say "hi" \o/`))
+}
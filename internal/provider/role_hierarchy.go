@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = &roleHierarchyResource{}
+	_ resource.ResourceWithConfigure   = &roleHierarchyResource{}
+	_ resource.ResourceWithImportState = &roleHierarchyResource{}
+	_ resource.ResourceWithModifyPlan  = &roleHierarchyResource{}
+)
+
+type roleHierarchyResource struct {
+	client *util.Client
+}
+
+func NewRoleHierarchyResource() resource.Resource {
+	return &roleHierarchyResource{}
+}
+
+func (r *roleHierarchyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*util.Client)
+}
+
+type roleHierarchyModel struct {
+	Id       types.String `tfsdk:"id"`
+	RoleName types.String `tfsdk:"role_name"`
+	Children types.Set    `tfsdk:"children"`
+}
+
+func (r *roleHierarchyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_hierarchy"
+}
+
+func (r *roleHierarchyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The full set of child roles of a role. Children not listed are removed from the role. " +
+			"Don't combine with tabular_role_relationship resources for the same parent role.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Terraform resource id",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				Description: "Parent role name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"children": schema.SetAttribute{
+				Description: "Names of the child roles. Planning fails if the children, together with the relationships " +
+					"that already exist, would form a cycle.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *roleHierarchyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	state := roleHierarchyModel{
+		Id:       types.StringValue(req.ID),
+		RoleName: types.StringValue(req.ID),
+		Children: types.SetUnknown(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *roleHierarchyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider has been configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan roleHierarchyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.RoleName.IsUnknown() || plan.Children.IsUnknown() {
+		return
+	}
+	var children []string
+	resp.Diagnostics.Append(plan.Children.ElementsAs(ctx, &children, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cycle, err := findRoleCycle(plan.RoleName.ValueString(), children, func(roleName string) ([]string, error) {
+		role, err := r.client.V1.GetRole(roleName)
+		// Roles that don't exist yet have no children
+		if err != nil || role == nil {
+			return nil, err
+		}
+		return internal.Map(role.Children, func(c tabular.Role) string { return c.Name }), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not walk the role hierarchy: "+err.Error())
+		return
+	}
+	if cycle != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("children"),
+			"Role hierarchy cycle",
+			fmt.Sprintf("Making %s a child of %s would create a cycle: %s", cycle[1], cycle[0], strings.Join(cycle, " -> ")),
+		)
+	}
+}
+
+func (r *roleHierarchyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleHierarchyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := state.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	children := internal.Map(role.Children, func(c tabular.Role) string { return c.Name })
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("children"), children)...)
+}
+
+func (r *roleHierarchyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleHierarchyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var children []string
+	resp.Diagnostics.Append(plan.Children.ElementsAs(ctx, &children, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := plan.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if role == nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+
+	// The hierarchy owns every child of the role, including the ones that were there before
+	existingChildren := internal.Map(role.Children, func(c tabular.Role) string { return c.Name })
+	r.reconcileChildren(roleName, existingChildren, children, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(roleName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *roleHierarchyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state roleHierarchyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var planChildren, stateChildren []string
+	resp.Diagnostics.Append(plan.Children.ElementsAs(ctx, &planChildren, false)...)
+	resp.Diagnostics.Append(state.Children.ElementsAs(ctx, &stateChildren, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied := r.reconcileChildren(state.RoleName.ValueString(), stateChildren, planChildren, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// Record the children the role has after the changes that went through, so the next plan retries the rest
+		var diags diag.Diagnostics
+		state.Children, diags = types.SetValueFrom(ctx, types.StringType, applied)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *roleHierarchyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleHierarchyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var children []string
+	resp.Diagnostics.Append(state.Children.ElementsAs(ctx, &children, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remaining := r.reconcileChildren(state.RoleName.ValueString(), children, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		var diags diag.Diagnostics
+		state.Children, diags = types.SetValueFrom(ctx, types.StringType, remaining)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// reconcileChildren removes the children of roleName that aren't wanted, then adds the ones that are missing. It
+// returns the children the role has afterwards, which on failure are the ones changed so far applied to current.
+func (r *roleHierarchyResource) reconcileChildren(roleName string, current, wanted []string, diags *diag.Diagnostics) []string {
+	return applyChildChanges(current, wanted,
+		func(child string) error {
+			err := r.client.V1.DeleteRoleRelation(roleName, child)
+			if err != nil {
+				diags.AddError("Error removing child role", fmt.Sprintf("Could not remove %s from %s: %s", child, roleName, err.Error()))
+			}
+			return err
+		},
+		func(child string) error {
+			err := r.client.V1.AddRoleRelation(roleName, child)
+			if err != nil {
+				diags.AddError("Error adding child role", fmt.Sprintf("Could not add %s to %s: %s", child, roleName, err.Error()))
+			}
+			return err
+		},
+	)
+}
+
+// applyChildChanges removes the current children that aren't wanted and adds the wanted ones that are missing,
+// stopping at the first error. It returns the children after the changes that were applied.
+func applyChildChanges(current, wanted []string, remove, add func(child string) error) []string {
+	applied := slices.Clone(current)
+	for _, child := range internal.Difference(current, wanted) {
+		if remove(child) != nil {
+			return applied
+		}
+		applied = internal.Filter(applied, func(c string) bool { return c != child })
+	}
+	for _, child := range internal.Difference(wanted, current) {
+		if add(child) != nil {
+			return applied
+		}
+		applied = append(applied, child)
+	}
+	return applied
+}
+
+// findRoleCycle walks down the hierarchy from each of the given children of roleName, using the roles' existing
+// children further down. It returns the path from roleName back to itself when a child leads back to it, or nil
+// when the children don't form a cycle.
+func findRoleCycle(roleName string, children []string, getChildren func(string) ([]string, error)) ([]string, error) {
+	visited := make(map[string]bool)
+	var walk func(path []string) ([]string, error)
+	walk = func(path []string) ([]string, error) {
+		current := path[len(path)-1]
+		if current == roleName {
+			return path, nil
+		}
+		if visited[current] {
+			return nil, nil
+		}
+		visited[current] = true
+
+		currentChildren, err := getChildren(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range currentChildren {
+			cycle, err := walk(append(slices.Clone(path), child))
+			if cycle != nil || err != nil {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+
+	for _, child := range children {
+		cycle, err := walk([]string{roleName, child})
+		if cycle != nil || err != nil {
+			return cycle, err
+		}
+	}
+	return nil, nil
+}
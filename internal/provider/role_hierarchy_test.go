@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRoleCycle(t *testing.T) {
+	existing := map[string][]string{
+		"engineering": {"data"},
+		"data":        {"analysts", "ml"},
+		"ml":          {"interns"},
+		"analysts":    {"interns"},
+	}
+	getChildren := func(roleName string) ([]string, error) { return existing[roleName], nil }
+
+	cycle, err := findRoleCycle("interns", []string{"engineering"}, getChildren)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"interns", "engineering", "data", "analysts", "interns"}, cycle)
+
+	cycle, err = findRoleCycle("data", []string{"data"}, getChildren)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"data", "data"}, cycle)
+
+	// The parent's existing children are replaced by the declared ones
+	cycle, err = findRoleCycle("engineering", []string{"ml", "analysts"}, getChildren)
+	assert.NoError(t, err)
+	assert.Nil(t, cycle)
+}
+
+func TestApplyChildChanges(t *testing.T) {
+	var removed, added []string
+	remove := func(child string) error { removed = append(removed, child); return nil }
+	add := func(child string) error {
+		if child == "broken" {
+			return errors.New("failed")
+		}
+		added = append(added, child)
+		return nil
+	}
+
+	assert.ElementsMatch(t, []string{"data", "ml"}, applyChildChanges([]string{"data", "interns"}, []string{"data", "ml"}, remove, add))
+	assert.Equal(t, []string{"interns"}, removed)
+	assert.Equal(t, []string{"ml"}, added)
+
+	// A failed add leaves the removal that went through and the adds before it
+	applied := applyChildChanges([]string{"data", "interns"}, []string{"analysts", "broken"}, remove, add)
+	assert.ElementsMatch(t, []string{"analysts"}, applied)
+
+	applied = applyChildChanges([]string{"data"}, nil, func(string) error { return errors.New("failed") }, add)
+	assert.Equal(t, []string{"data"}, applied)
+}
@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/planmodifiers"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"strings"
+)
+
+var (
+	_ resource.Resource                   = &roleMemberResource{}
+	_ resource.ResourceWithConfigure      = &roleMemberResource{}
+	_ resource.ResourceWithImportState    = &roleMemberResource{}
+	_ resource.ResourceWithValidateConfig = &roleMemberResource{}
+)
+
+type roleMemberResource struct {
+	client *util.Client
+}
+
+func NewRoleMemberResource() resource.Resource {
+	return &roleMemberResource{}
+}
+
+func (r *roleMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*util.Client)
+}
+
+type roleMemberModel struct {
+	Id             types.String `tfsdk:"id"`
+	RoleName       types.String `tfsdk:"role_name"`
+	Email          types.String `tfsdk:"email"`
+	MemberId       types.String `tfsdk:"member_id"`
+	ServiceAccount types.String `tfsdk:"service_account"`
+	WithAdmin      types.Bool   `tfsdk:"with_admin"`
+}
+
+func (r *roleMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_member"
+}
+
+func (r *roleMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Grant a single user or service account access to a role, without managing the role's other members",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Terraform resource id",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				Description: "Role name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description: "Email of the member, case-insensitive. Exactly one of email, member_id or service_account must be set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"member_id": schema.StringAttribute{
+				Description: "Member ID. Exactly one of email, member_id or service_account must be set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_account": schema.StringAttribute{
+				Description: "Credential ID or name of a service account. Exactly one of email, member_id or service_account must be set.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"with_admin": schema.BoolAttribute{
+				Description: "Whether the member can administer the role. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					planmodifiers.BoolDefaultModifier{Default: false},
+				},
+			},
+		},
+	}
+}
+
+func (r *roleMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	separator := strings.LastIndex(req.ID, "/")
+	if separator <= 0 || separator == len(req.ID)-1 {
+		resp.Diagnostics.AddError("Invalid role member specifier", "Expected roleName/email or roleName/memberId")
+		return
+	}
+	roleName := req.ID[:separator]
+	member := req.ID[separator+1:]
+
+	state := roleMemberModel{
+		RoleName:       types.StringValue(roleName),
+		Email:          types.StringNull(),
+		MemberId:       types.StringNull(),
+		ServiceAccount: types.StringNull(),
+		WithAdmin:      types.BoolUnknown(),
+	}
+	if strings.Contains(member, "@") {
+		state.Email = types.StringValue(member)
+	} else {
+		state.MemberId = types.StringValue(member)
+	}
+	state.Id = types.StringValue(req.ID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *roleMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config roleMemberModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Email.IsUnknown() || config.MemberId.IsUnknown() || config.ServiceAccount.IsUnknown() {
+		return
+	}
+	configured := 0
+	for _, v := range []types.String{config.Email, config.MemberId, config.ServiceAccount} {
+		if !v.IsNull() {
+			configured++
+		}
+	}
+	if configured != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("email"),
+			"Invalid role member",
+			"Exactly one of email, member_id or service_account must be set",
+		)
+	}
+}
+
+func (r *roleMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleMemberModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := state.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	member := findRoleMember(role.Members, state.MemberId.ValueString(), state.Email.ValueString())
+	if member == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(fmt.Sprintf("%s/%s", roleName, member.Id))
+	state.MemberId = types.StringValue(member.Id)
+	// Service accounts have no email
+	if member.Email == "" {
+		state.Email = types.StringNull()
+	} else if !strings.EqualFold(state.Email.ValueString(), member.Email) {
+		state.Email = types.StringValue(member.Email)
+	}
+	state.WithAdmin = types.BoolValue(member.WithAdmin)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *roleMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgMemberMap, err := r.client.V1.GetOrgMemberIdsMap()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
+		return
+	}
+
+	if !plan.ServiceAccount.IsNull() {
+		serviceAccounts, err := lookupServiceAccounts(ctx, r.client, []string{plan.ServiceAccount.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+			return
+		}
+		serviceAccount, err := findServiceAccount(serviceAccounts, plan.ServiceAccount.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("service_account"), "Error adding service account", err.Error())
+			return
+		}
+		plan.MemberId = types.StringValue(serviceAccount.MemberId)
+		plan.Email = types.StringNull()
+	} else if plan.MemberId.IsUnknown() {
+		email := plan.Email.ValueString()
+		memberId, ok := orgMemberMap[strings.ToLower(email)]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email"),
+				"Error adding user",
+				fmt.Sprintf("Could not find user with email %s in org", email),
+			)
+			return
+		}
+		plan.MemberId = types.StringValue(memberId)
+	} else {
+		memberId := plan.MemberId.ValueString()
+		plan.Email = types.StringNull()
+		for email, id := range orgMemberMap {
+			if id == memberId {
+				plan.Email = types.StringValue(email)
+			}
+		}
+	}
+
+	roleName := plan.RoleName.ValueString()
+	err = r.addMember(roleName, plan.MemberId.ValueString(), plan.WithAdmin.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding role member", err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", roleName, plan.MemberId.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *roleMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state roleMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only with_admin can change without replacing the resource. Adding an existing member doesn't change its admin
+	// flag, so the member is removed and added again.
+	roleName := state.RoleName.ValueString()
+	toRemove, adminToAdd, toAdd := roleMemberUpdate(state.MemberId.ValueString(), state.WithAdmin.ValueBool(), plan.WithAdmin.ValueBool())
+	err := r.client.V1.DeleteRoleMembers(roleName, toRemove)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating role member", err.Error())
+		return
+	}
+	err = r.client.V1.AddRoleMembers(roleName, adminToAdd, toAdd)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating role member", err.Error())
+		// The member is no longer in the role, so the next apply adds it again
+		if len(toRemove) > 0 {
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	state.WithAdmin = plan.WithAdmin
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *roleMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.V1.DeleteRoleMembers(state.RoleName.ValueString(), []string{state.MemberId.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing role member", err.Error())
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *roleMemberResource) addMember(roleName, memberId string, withAdmin bool) error {
+	if withAdmin {
+		return r.client.V1.AddRoleMembers(roleName, []string{memberId}, nil)
+	}
+	return r.client.V1.AddRoleMembers(roleName, nil, []string{memberId})
+}
+
+// roleMemberUpdate returns the changes that move a member's admin flag from wasAdmin to withAdmin
+func roleMemberUpdate(memberId string, wasAdmin, withAdmin bool) (toRemove, adminToAdd, toAdd []string) {
+	split := func(admin bool) ([]string, []string) {
+		if admin {
+			return []string{memberId}, nil
+		}
+		return nil, []string{memberId}
+	}
+	stateAdminMemberIds, stateMemberIds := split(wasAdmin)
+	planAdminMemberIds, planMemberIds := split(withAdmin)
+	return roleMemberChanges(stateAdminMemberIds, stateMemberIds, planAdminMemberIds, planMemberIds)
+}
+
+// findRoleMember looks a member up by id, falling back to email when the id isn't known yet (e.g. after an import).
+func findRoleMember(members []tabular.Member, memberId, email string) *tabular.Member {
+	for i, m := range members {
+		if memberId != "" && m.Id == memberId {
+			return &members[i]
+		}
+		if memberId == "" && strings.EqualFold(m.Email, email) {
+			return &members[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func TestFindRoleMember(t *testing.T) {
+	members := []tabular.Member{
+		{Id: "1", Email: "one@example.com", WithAdmin: true},
+		{Id: "2", Email: "two@example.com"},
+	}
+
+	assert.Equal(t, "2", findRoleMember(members, "2", "").Id)
+	assert.Equal(t, "1", findRoleMember(members, "", "one@example.com").Id)
+	assert.Equal(t, "1", findRoleMember(members, "", "One@Example.com").Id)
+	// A known id takes precedence over a stale email
+	assert.Equal(t, "2", findRoleMember(members, "2", "one@example.com").Id)
+	assert.Nil(t, findRoleMember(members, "3", ""))
+	assert.Nil(t, findRoleMember(members, "", "three@example.com"))
+}
+
+func TestRoleMemberUpdate(t *testing.T) {
+	// Demoting an admin removes the member before adding it back without admin
+	toRemove, adminToAdd, toAdd := roleMemberUpdate("1", true, false)
+	assert.Equal(t, []string{"1"}, toRemove)
+	assert.Empty(t, adminToAdd)
+	assert.Equal(t, []string{"1"}, toAdd)
+
+	toRemove, adminToAdd, toAdd = roleMemberUpdate("1", false, true)
+	assert.Equal(t, []string{"1"}, toRemove)
+	assert.Equal(t, []string{"1"}, adminToAdd)
+	assert.Empty(t, toAdd)
+
+	toRemove, adminToAdd, toAdd = roleMemberUpdate("1", true, true)
+	assert.Empty(t, toRemove)
+	assert.Empty(t, adminToAdd)
+	assert.Empty(t, toAdd)
+}
@@ -3,15 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
 	"github.com/tabular-io/terraform-provider-tabular/internal"
 	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/validators"
 	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	"strings"
 )
 
 var (
@@ -38,9 +44,25 @@ func (r *roleMembershipResource) Configure(ctx context.Context, req resource.Con
 }
 
 type roleMembershipModel struct {
-	RoleName     types.String `tfsdk:"role_name"`
-	AdminMembers types.Set    `tfsdk:"admin_members"`
-	Members      types.Set    `tfsdk:"members"`
+	RoleName            types.String `tfsdk:"role_name"`
+	AdminMembers        types.Set    `tfsdk:"admin_members"`
+	Members             types.Set    `tfsdk:"members"`
+	AdminMemberIds      types.Set    `tfsdk:"admin_member_ids"`
+	MemberIds           types.Set    `tfsdk:"member_ids"`
+	ServiceAccounts     types.Set    `tfsdk:"service_accounts"`
+	Mode                types.String `tfsdk:"mode"`
+	DeferMissingMembers types.Bool   `tfsdk:"defer_missing_members"`
+	PendingMembers      types.Set    `tfsdk:"pending_members"`
+}
+
+const (
+	roleMembershipModeAuthoritative = "authoritative"
+	roleMembershipModeAdditive      = "additive"
+)
+
+// An unset mode keeps the original behaviour of owning the role's full member set
+func (m roleMembershipModel) isAdditive() bool {
+	return m.Mode.ValueString() == roleMembershipModeAdditive
 }
 
 func (r *roleMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,11 +81,49 @@ func (r *roleMembershipResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"admin_members": schema.SetAttribute{
-				Required:    true,
+				Description: "Emails of members who can administer the role. Emails are case-insensitive.",
+				Optional:    true,
 				ElementType: types.StringType,
 			},
 			"members": schema.SetAttribute{
-				Required:    true,
+				Description: "Emails of members. Emails are case-insensitive.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"admin_member_ids": schema.SetAttribute{
+				Description: "IDs of members who can administer the role. Unlike emails, member IDs don't change when a user changes their email address.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"member_ids": schema.SetAttribute{
+				Description: "IDs of members. Unlike emails, member IDs don't change when a user changes their email address.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"service_accounts": schema.SetAttribute{
+				Description: "Service accounts to add as (non-admin) members, by credential ID or name",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"mode": schema.StringAttribute{
+				Description: "Either authoritative or additive. Authoritative membership owns every member of the role and " +
+					"removes any member not listed. Additive membership only ensures the listed members exist, so several " +
+					"resources can add members to the same role. In both modes destroying the resource only removes the " +
+					"members recorded in state. Defaults to authoritative.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.StringOneOfValidator{Values: []string{roleMembershipModeAuthoritative, roleMembershipModeAdditive}},
+				},
+			},
+			"defer_missing_members": schema.BoolAttribute{
+				Description: "Instead of failing on emails that haven't joined the org yet, track them in pending_members and " +
+					"add them to the role on the first apply after they join. Invitations to the org still have to be sent " +
+					"from Tabular. Defaults to false.",
+				Optional: true,
+			},
+			"pending_members": schema.SetAttribute{
+				Description: "Emails from admin_members and members that haven't joined the org yet",
+				Computed:    true,
 				ElementType: types.StringType,
 			},
 		},
@@ -72,9 +132,13 @@ func (r *roleMembershipResource) Schema(ctx context.Context, req resource.Schema
 
 func (r *roleMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	state := roleMembershipModel{
-		RoleName:     types.StringValue(req.ID),
-		AdminMembers: types.SetUnknown(types.StringType),
-		Members:      types.SetUnknown(types.StringType),
+		RoleName:        types.StringValue(req.ID),
+		AdminMembers:    types.SetUnknown(types.StringType),
+		Members:         types.SetUnknown(types.StringType),
+		AdminMemberIds:  types.SetNull(types.StringType),
+		MemberIds:       types.SetNull(types.StringType),
+		ServiceAccounts: types.SetNull(types.StringType),
+		PendingMembers:  types.SetNull(types.StringType),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
@@ -99,17 +163,91 @@ func (r *roleMembershipResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	adminMembers := internal.Map(
-		internal.Filter(role.Members, func(m tabular.Member) bool { return m.WithAdmin }),
-		func(m tabular.Member) string { return m.Email },
-	)
-	members := internal.Map(
-		internal.Filter(role.Members, func(m tabular.Member) bool { return !m.WithAdmin }),
-		func(m tabular.Member) string { return m.Email },
-	)
-	state.AdminMembers, diags = types.SetValueFrom(ctx, types.StringType, adminMembers)
+	// Report service accounts the way they were configured, by credential id or by name. Without any configured,
+	// service accounts in the role are reported like other members.
+	var stateServiceAccounts []string
+	resp.Diagnostics.Append(state.ServiceAccounts.ElementsAs(ctx, &stateServiceAccounts, true)...)
+	serviceAccounts, err := lookupServiceAccounts(ctx, r.client, stateServiceAccounts)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+		return
+	}
+	serviceAccountsByMemberId := make(map[string]tabular.Credential, len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		serviceAccountsByMemberId[serviceAccount.MemberId] = serviceAccount
+	}
+	configuredServiceAccounts := make(map[string]string, len(stateServiceAccounts))
+	for _, identifier := range stateServiceAccounts {
+		if serviceAccount, err := findServiceAccount(serviceAccounts, identifier); err == nil {
+			configuredServiceAccounts[serviceAccount.MemberId] = identifier
+		}
+	}
+
+	var stateAdminMemberEmails, stateMemberEmails, stateAdminMemberIds, stateMemberIds []string
+	resp.Diagnostics.Append(state.AdminMembers.ElementsAs(ctx, &stateAdminMemberEmails, true)...)
+	resp.Diagnostics.Append(state.Members.ElementsAs(ctx, &stateMemberEmails, true)...)
+	resp.Diagnostics.Append(state.AdminMemberIds.ElementsAs(ctx, &stateAdminMemberIds, true)...)
+	resp.Diagnostics.Append(state.MemberIds.ElementsAs(ctx, &stateMemberIds, true)...)
+	stateEmails := append(stateAdminMemberEmails, stateMemberEmails...)
+	stateIds := append(stateAdminMemberIds, stateMemberIds...)
+
+	roleMembers, serviceAccountMembers := splitServiceAccountMembers(role.Members, serviceAccountsByMemberId,
+		configuredServiceAccounts, stateIds, state.isAdditive())
+
+	// Members still waiting to join the org are reported as configured. Once they've joined they show up as missing
+	// from the role, so the next apply adds them.
+	var statePendingMembers []string
+	resp.Diagnostics.Append(state.PendingMembers.ElementsAs(ctx, &statePendingMembers, true)...)
+	pendingMembers := make([]string, 0)
+	if len(statePendingMembers) > 0 {
+		orgMemberMap, err := r.client.V1.GetOrgMemberIdsMap()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
+			return
+		}
+		pendingMembers = internal.Filter(statePendingMembers, func(email string) bool {
+			_, ok := orgMemberMap[strings.ToLower(email)]
+			return !ok
+		})
+	}
+
+	adminMembers, members := make([]string, 0), make([]string, 0)
+	adminMemberIds, memberIds := make([]string, 0), make([]string, 0)
+	for _, m := range roleMembers {
+		switch {
+		// Members configured by id are reported by id, everyone else by email
+		case slices.Contains(stateIds, m.Id) && m.WithAdmin:
+			adminMemberIds = append(adminMemberIds, m.Id)
+		case slices.Contains(stateIds, m.Id):
+			memberIds = append(memberIds, m.Id)
+		// Members added outside of this resource aren't ours to report
+		case state.isAdditive() && !containsEmail(stateEmails, m.Email):
+		case m.WithAdmin:
+			adminMembers = append(adminMembers, configuredEmail(stateEmails, m.Email))
+		default:
+			members = append(members, configuredEmail(stateEmails, m.Email))
+		}
+	}
+
+	for _, email := range pendingMembers {
+		if containsEmail(stateAdminMemberEmails, email) {
+			adminMembers = append(adminMembers, email)
+		} else {
+			members = append(members, email)
+		}
+	}
+
+	state.AdminMembers, diags = membershipSetValue(ctx, state.AdminMembers, adminMembers)
+	resp.Diagnostics.Append(diags...)
+	state.Members, diags = membershipSetValue(ctx, state.Members, members)
+	resp.Diagnostics.Append(diags...)
+	state.AdminMemberIds, diags = membershipSetValue(ctx, state.AdminMemberIds, adminMemberIds)
+	resp.Diagnostics.Append(diags...)
+	state.MemberIds, diags = membershipSetValue(ctx, state.MemberIds, memberIds)
+	resp.Diagnostics.Append(diags...)
+	state.ServiceAccounts, diags = membershipSetValue(ctx, state.ServiceAccounts, serviceAccountMembers)
 	resp.Diagnostics.Append(diags...)
-	state.Members, diags = types.SetValueFrom(ctx, types.StringType, members)
+	state.PendingMembers, diags = types.SetValueFrom(ctx, types.StringType, pendingMembers)
 	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -120,18 +258,26 @@ func (r *roleMembershipResource) ValidateConfig(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if plan.AdminMembers.IsUnknown() || plan.AdminMembers.IsNull() || plan.Members.IsUnknown() || plan.Members.IsNull() {
-		return
-	}
-	var adminMemberEmails, memberEmails []string
-	resp.Diagnostics.Append(plan.AdminMembers.ElementsAs(ctx, &adminMemberEmails, false)...)
-	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &memberEmails, false)...)
-	if resp.Diagnostics.HasError() {
-		return
+	if !plan.AdminMembers.IsUnknown() && !plan.Members.IsUnknown() {
+		var adminMemberEmails, memberEmails []string
+		resp.Diagnostics.Append(plan.AdminMembers.ElementsAs(ctx, &adminMemberEmails, true)...)
+		resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &memberEmails, true)...)
+		intersection := internal.Intersection(
+			internal.Map(adminMemberEmails, strings.ToLower),
+			internal.Map(memberEmails, strings.ToLower),
+		)
+		if len(intersection) > 0 {
+			resp.Diagnostics.AddError("Found members present in both admin_members and members", fmt.Sprintf("%s", intersection))
+		}
 	}
-	intersection := internal.Intersection(adminMemberEmails, memberEmails)
-	if intersection != nil && len(intersection) > 0 {
-		resp.Diagnostics.AddError("Found members present in both admin_members and members", fmt.Sprintf("%s", intersection))
+	if !plan.AdminMemberIds.IsUnknown() && !plan.MemberIds.IsUnknown() {
+		var adminMemberIds, memberIds []string
+		resp.Diagnostics.Append(plan.AdminMemberIds.ElementsAs(ctx, &adminMemberIds, true)...)
+		resp.Diagnostics.Append(plan.MemberIds.ElementsAs(ctx, &memberIds, true)...)
+		intersection := internal.Intersection(adminMemberIds, memberIds)
+		if len(intersection) > 0 {
+			resp.Diagnostics.AddError("Found members present in both admin_member_ids and member_ids", fmt.Sprintf("%s", intersection))
+		}
 	}
 }
 
@@ -141,9 +287,12 @@ func (r *roleMembershipResource) Create(ctx context.Context, req resource.Create
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	var adminMemberEmails, memberEmails []string
+	var adminMemberEmails, memberEmails, configuredAdminMemberIds, configuredMemberIds, serviceAccountIdentifiers []string
 	resp.Diagnostics.Append(plan.AdminMembers.ElementsAs(ctx, &adminMemberEmails, false)...)
 	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &memberEmails, false)...)
+	resp.Diagnostics.Append(plan.AdminMemberIds.ElementsAs(ctx, &configuredAdminMemberIds, false)...)
+	resp.Diagnostics.Append(plan.MemberIds.ElementsAs(ctx, &configuredMemberIds, false)...)
+	resp.Diagnostics.Append(plan.ServiceAccounts.ElementsAs(ctx, &serviceAccountIdentifiers, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -153,27 +302,42 @@ func (r *roleMembershipResource) Create(ctx context.Context, req resource.Create
 		resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
 		return
 	}
-	adminMemberIds := mapMemberEmailsToIds(adminMemberEmails, orgMemberMap, func(email string) {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("admin_members"),
-			"Error adding user",
-			fmt.Sprintf("Could not find user with email %s in org", email),
-		)
-	})
-	memberIds := mapMemberEmailsToIds(memberEmails, orgMemberMap, func(email string) {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("members"),
-			"Error adding user",
-			fmt.Sprintf("Could not find user with email %s in org", email),
-		)
+	pendingMembers := make([]string, 0)
+	deferMissing := plan.DeferMissingMembers.ValueBool()
+	adminMemberIds := mapMemberEmailsToIds(adminMemberEmails, orgMemberMap,
+		missingMemberHandler(&resp.Diagnostics, "admin_members", deferMissing, &pendingMembers))
+	memberIds := mapMemberEmailsToIds(memberEmails, orgMemberMap,
+		missingMemberHandler(&resp.Diagnostics, "members", deferMissing, &pendingMembers))
+
+	serviceAccounts, err := lookupServiceAccounts(ctx, r.client, serviceAccountIdentifiers)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+		return
+	}
+	serviceAccountMemberIds := mapServiceAccountsToMemberIds(serviceAccountIdentifiers, serviceAccounts, func(identifier string, err error) {
+		resp.Diagnostics.AddAttributeError(path.Root("service_accounts"), "Error adding service account", err.Error())
 	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	adminMemberIds = append(adminMemberIds, configuredAdminMemberIds...)
+	memberIds = append(append(memberIds, configuredMemberIds...), serviceAccountMemberIds...)
 	err = r.client.V1.AddRoleMembers(plan.RoleName.ValueString(), adminMemberIds, memberIds)
 	if err != nil {
 		resp.Diagnostics.AddError("Error adding role members", err.Error())
 		return
 	}
 
+	err = r.removeUnmanagedMembers(plan, append(adminMemberIds, memberIds...))
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing unmanaged role members", err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	plan.PendingMembers, diags = types.SetValueFrom(ctx, types.StringType, pendingMembers)
+	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -192,6 +356,14 @@ func (r *roleMembershipResource) Update(ctx context.Context, req resource.Update
 	resp.Diagnostics.Append(state.AdminMembers.ElementsAs(ctx, &stateAdminMemberEmails, false)...)
 	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &planMemberEmails, false)...)
 	resp.Diagnostics.Append(state.Members.ElementsAs(ctx, &stateMemberEmails, false)...)
+	var planConfiguredAdminMemberIds, planConfiguredMemberIds, stateConfiguredAdminMemberIds, stateConfiguredMemberIds []string
+	resp.Diagnostics.Append(plan.AdminMemberIds.ElementsAs(ctx, &planConfiguredAdminMemberIds, false)...)
+	resp.Diagnostics.Append(state.AdminMemberIds.ElementsAs(ctx, &stateConfiguredAdminMemberIds, false)...)
+	resp.Diagnostics.Append(plan.MemberIds.ElementsAs(ctx, &planConfiguredMemberIds, false)...)
+	resp.Diagnostics.Append(state.MemberIds.ElementsAs(ctx, &stateConfiguredMemberIds, false)...)
+	var planServiceAccounts, stateServiceAccounts []string
+	resp.Diagnostics.Append(plan.ServiceAccounts.ElementsAs(ctx, &planServiceAccounts, false)...)
+	resp.Diagnostics.Append(state.ServiceAccounts.ElementsAs(ctx, &stateServiceAccounts, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -201,55 +373,58 @@ func (r *roleMembershipResource) Update(ctx context.Context, req resource.Update
 		resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
 		return
 	}
-	planAdminMemberIds := mapMemberEmailsToIds(planAdminMemberEmails, orgMemberMap, func(email string) {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("admin_members"),
-			"Error adding user",
-			fmt.Sprintf("Could not find user with email %s in org", email),
-		)
-	})
-	stateAdminMemberIds := mapMemberEmailsToIds(stateAdminMemberEmails, orgMemberMap, func(email string) {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("admin_members"),
-			"Error adding user",
-			fmt.Sprintf("Could not find user with email %s in org", email),
-		)
-	})
-	planMemberIds := mapMemberEmailsToIds(planMemberEmails, orgMemberMap, func(email string) {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("members"),
-			"Error adding user",
-			fmt.Sprintf("Could not find user wit`h email %s in org", email),
-		)
-	})
-	stateMemberIds := mapMemberEmailsToIds(stateMemberEmails, orgMemberMap, func(email string) {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("members"),
-			"Error adding user",
-			fmt.Sprintf("Could not find user with email %s in org", email),
-		)
+	pendingMembers := make([]string, 0)
+	deferMissing := plan.DeferMissingMembers.ValueBool()
+	planAdminMemberIds := mapMemberEmailsToIds(planAdminMemberEmails, orgMemberMap,
+		missingMemberHandler(&resp.Diagnostics, "admin_members", deferMissing, &pendingMembers))
+	planMemberIds := mapMemberEmailsToIds(planMemberEmails, orgMemberMap,
+		missingMemberHandler(&resp.Diagnostics, "members", deferMissing, &pendingMembers))
+	// Members that haven't joined the org, or have since left it, can't be in the role, so there's nothing to remove for them
+	stateAdminMemberIds := mapMemberEmailsToIds(stateAdminMemberEmails, orgMemberMap, func(string) {})
+	stateMemberIds := mapMemberEmailsToIds(stateMemberEmails, orgMemberMap, func(string) {})
+
+	serviceAccounts, err := lookupServiceAccounts(ctx, r.client,
+		append(internal.Difference(planServiceAccounts, stateServiceAccounts), stateServiceAccounts...))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+		return
+	}
+	planServiceAccountIds := mapServiceAccountsToMemberIds(planServiceAccounts, serviceAccounts, func(identifier string, err error) {
+		resp.Diagnostics.AddAttributeError(path.Root("service_accounts"), "Error adding service account", err.Error())
 	})
+	// Service accounts deleted since the last apply can't still be members, so there's nothing to remove for them
+	stateServiceAccountIds := mapServiceAccountsToMemberIds(stateServiceAccounts, serviceAccounts, func(string, error) {})
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	adminToRemove := internal.Difference(stateAdminMemberIds, planAdminMemberIds)
-	toRemove := internal.Difference(stateMemberIds, planMemberIds)
-	// TODO: do I need to dedupe removals? Are duplicates even possible?
-	err = r.client.V1.DeleteRoleMembers(state.RoleName.ValueString(), append(adminToRemove, toRemove...))
+	planAdminMemberIds = append(planAdminMemberIds, planConfiguredAdminMemberIds...)
+	stateAdminMemberIds = append(stateAdminMemberIds, stateConfiguredAdminMemberIds...)
+	planMemberIds = append(append(planMemberIds, planConfiguredMemberIds...), planServiceAccountIds...)
+	stateMemberIds = append(append(stateMemberIds, stateConfiguredMemberIds...), stateServiceAccountIds...)
+	toRemove, adminToAdd, toAdd := roleMemberChanges(stateAdminMemberIds, stateMemberIds, planAdminMemberIds, planMemberIds)
+	err = r.client.V1.DeleteRoleMembers(state.RoleName.ValueString(), toRemove)
 	if err != nil {
 		resp.Diagnostics.AddError("Error removing role members", err.Error())
 		return
 	}
 
-	adminToAdd := internal.Difference(planAdminMemberIds, stateAdminMemberIds)
-	toAdd := internal.Difference(planMemberIds, stateMemberIds)
 	err = r.client.V1.AddRoleMembers(state.RoleName.ValueString(), adminToAdd, toAdd)
 	if err != nil {
 		resp.Diagnostics.AddError("Error adding role members", err.Error())
 		return
 	}
 
+	// Switching from additive mode leaves unmanaged members that were never recorded in state
+	err = r.removeUnmanagedMembers(plan, append(planAdminMemberIds, planMemberIds...))
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing unmanaged role members", err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	plan.PendingMembers, diags = types.SetValueFrom(ctx, types.StringType, pendingMembers)
+	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -262,38 +437,118 @@ func (r *roleMembershipResource) Delete(ctx context.Context, req resource.Delete
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	var memberEmails []string
+	var adminMemberEmails, memberEmails, adminMemberIds, memberIds, serviceAccountIdentifiers []string
+	resp.Diagnostics.Append(state.AdminMembers.ElementsAs(ctx, &adminMemberEmails, false)...)
 	resp.Diagnostics.Append(state.Members.ElementsAs(ctx, &memberEmails, false)...)
+	resp.Diagnostics.Append(state.AdminMemberIds.ElementsAs(ctx, &adminMemberIds, false)...)
+	resp.Diagnostics.Append(state.MemberIds.ElementsAs(ctx, &memberIds, false)...)
+	resp.Diagnostics.Append(state.ServiceAccounts.ElementsAs(ctx, &serviceAccountIdentifiers, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	orgMemberMap, err := r.client.V1.GetOrgMemberIdsMap()
+	roleName := state.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+	if role == nil {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	memberIds := mapMemberEmailsToIds(memberEmails, orgMemberMap, func(email string) {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("members"),
-			"Error removing user",
-			fmt.Sprintf("Could not find user with email %s in org", email),
-		)
-	})
 
-	err = r.client.V1.DeleteRoleMembers(state.RoleName.ValueString(), memberIds)
+	// Only members recorded in state are removed, in either mode. Members added since the last refresh, or managed by
+	// other resources, are left alone.
+	serviceAccounts, err := lookupServiceAccounts(ctx, r.client, serviceAccountIdentifiers)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+		return
+	}
+	managedMemberIds := append(
+		append(adminMemberIds, memberIds...),
+		mapServiceAccountsToMemberIds(serviceAccountIdentifiers, serviceAccounts, func(string, error) {})...,
+	)
+	err = r.client.V1.DeleteRoleMembers(roleName, managedRoleMemberIds(role.Members, append(adminMemberEmails, memberEmails...), managedMemberIds))
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating role relation", err.Error())
+		resp.Diagnostics.AddError("Error removing role members", err.Error())
 		return
 	}
 
 	resp.State.RemoveResource(ctx)
 }
 
+// removeUnmanagedMembers removes every member of the role whose id isn't in managedMemberIds. Additive membership
+// doesn't own the rest of the role, so it removes nothing.
+func (r *roleMembershipResource) removeUnmanagedMembers(plan roleMembershipModel, managedMemberIds []string) error {
+	if plan.isAdditive() {
+		return nil
+	}
+	roleName := plan.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return fmt.Errorf("could not fetch role %s", roleName)
+	}
+	return r.client.V1.DeleteRoleMembers(roleName, unmanagedRoleMemberIds(role.Members, managedMemberIds))
+}
+
+// roleMemberChanges diffs the member ids in state against the planned ones. A member whose admin flag changes is
+// removed and added again, since adding an existing member doesn't change it.
+func roleMemberChanges(stateAdminMemberIds, stateMemberIds, planAdminMemberIds, planMemberIds []string) (toRemove, adminToAdd, toAdd []string) {
+	toRemove = append(
+		internal.Difference(stateAdminMemberIds, planAdminMemberIds),
+		internal.Difference(stateMemberIds, planMemberIds)...,
+	)
+	adminToAdd = internal.Difference(planAdminMemberIds, stateAdminMemberIds)
+	toAdd = internal.Difference(planMemberIds, stateMemberIds)
+	return toRemove, adminToAdd, toAdd
+}
+
+// unmanagedRoleMemberIds returns the ids of the role's members that aren't in managedMemberIds
+func unmanagedRoleMemberIds(roleMembers []tabular.Member, managedMemberIds []string) []string {
+	return internal.Map(
+		internal.Filter(roleMembers, func(m tabular.Member) bool { return !slices.Contains(managedMemberIds, m.Id) }),
+		func(m tabular.Member) string { return m.Id },
+	)
+}
+
+// managedRoleMemberIds returns the ids of the role's members matching one of emails, ignoring case, or memberIds
+func managedRoleMemberIds(roleMembers []tabular.Member, emails, memberIds []string) []string {
+	return internal.Map(
+		internal.Filter(roleMembers, func(m tabular.Member) bool {
+			return containsEmail(emails, m.Email) || slices.Contains(memberIds, m.Id)
+		}),
+		func(m tabular.Member) string { return m.Id },
+	)
+}
+
+// splitServiceAccountMembers separates the service accounts reported in service_accounts, by the identifier they were
+// configured with, from the members reported by email or id. A service account configured by member id is reported by
+// member id like any other member. Additive membership leaves out service accounts it didn't add.
+func splitServiceAccountMembers(members []tabular.Member, serviceAccountsByMemberId map[string]tabular.Credential,
+	configuredServiceAccounts map[string]string, configuredMemberIds []string, additive bool) ([]tabular.Member, []string) {
+	roleMembers := make([]tabular.Member, 0, len(members))
+	serviceAccountMembers := make([]string, 0)
+	for _, m := range members {
+		serviceAccount, ok := serviceAccountsByMemberId[m.Id]
+		if !ok || slices.Contains(configuredMemberIds, m.Id) {
+			roleMembers = append(roleMembers, m)
+		} else if identifier, ok := configuredServiceAccounts[m.Id]; ok {
+			serviceAccountMembers = append(serviceAccountMembers, identifier)
+		} else if !additive {
+			serviceAccountMembers = append(serviceAccountMembers, serviceAccount.Key)
+		}
+	}
+	return roleMembers, serviceAccountMembers
+}
+
 func mapMemberEmailsToIds(memberEmails []string, memberIdMap map[string]string, errorHandler func(string)) []string {
 	memberIds := make([]string, 0, len(memberEmails))
 	for _, email := range memberEmails {
-		if val, ok := memberIdMap[email]; ok {
+		if val, ok := memberIdMap[strings.ToLower(email)]; ok {
 			memberIds = append(memberIds, val)
 		} else {
 			errorHandler(email)
@@ -301,3 +556,117 @@ func mapMemberEmailsToIds(memberEmails []string, memberIdMap map[string]string,
 	}
 	return memberIds
 }
+
+// missingMemberHandler reports an email that isn't in the org as an error on attribute, or collects it into pending
+// when missing members are deferred
+func missingMemberHandler(diags *diag.Diagnostics, attribute string, deferMissing bool, pending *[]string) func(string) {
+	return func(email string) {
+		if deferMissing {
+			*pending = append(*pending, email)
+			return
+		}
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Error adding user",
+			fmt.Sprintf("Could not find user with email %s in org", email),
+		)
+	}
+}
+
+// containsEmail reports whether emails contains email, ignoring case
+func containsEmail(emails []string, email string) bool {
+	return slices.ContainsFunc(emails, func(e string) bool { return strings.EqualFold(e, email) })
+}
+
+// configuredEmail returns email the way it's written in configuredEmails, so that a difference in case alone
+// doesn't show up as a diff
+func configuredEmail(configuredEmails []string, email string) string {
+	for _, e := range configuredEmails {
+		if strings.EqualFold(e, email) {
+			return e
+		}
+	}
+	return email
+}
+
+// membershipSetValue keeps an attribute that was left unset null as long as there are no members to report in it
+func membershipSetValue(ctx context.Context, current types.Set, values []string) (types.Set, diag.Diagnostics) {
+	if len(values) == 0 && current.IsNull() {
+		return current, nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, values)
+}
+
+func mapServiceAccountsToMemberIds(identifiers []string, serviceAccounts []tabular.Credential, errorHandler func(string, error)) []string {
+	memberIds := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		serviceAccount, err := findServiceAccount(serviceAccounts, identifier)
+		if err != nil {
+			errorHandler(identifier, err)
+			continue
+		}
+		memberIds = append(memberIds, serviceAccount.MemberId)
+	}
+	return memberIds
+}
+
+// lookupServiceAccounts fetches the service accounts identifiers refer to. Credential keys are fetched one at a time,
+// only identifiers that aren't a key, e.g. names, need every credential in the org listed. No identifiers means no
+// requests at all.
+func lookupServiceAccounts(ctx context.Context, client *util.Client, identifiers []string) ([]tabular.Credential, error) {
+	serviceAccounts := make([]tabular.Credential, 0, len(identifiers))
+	listAll := false
+	for _, identifier := range identifiers {
+		retryFunc := util.RetryResourceResponse[*tabularv2.GetCredentialResponse]
+		credential, httpResp, err := retryFunc(client.V2.DefaultAPI.GetCredential(ctx, *client.OrganizationId, identifier).Execute)
+		if err != nil && httpResp != nil && httpResp.StatusCode >= 400 && httpResp.StatusCode < 500 {
+			listAll = true
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch service account %s: %w", identifier, err)
+		}
+		serviceAccounts = append(serviceAccounts, tabular.Credential{
+			Id:       credential.GetId(),
+			Key:      credential.GetKey(),
+			MemberId: credential.GetMemberId(),
+			RoleId:   credential.GetRoleId(),
+			Name:     credential.GetName(),
+			Type:     credential.GetType(),
+		})
+	}
+	if !listAll {
+		return serviceAccounts, nil
+	}
+
+	listed, err := client.V1.GetServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+	for _, serviceAccount := range listed {
+		if !slices.ContainsFunc(serviceAccounts, func(c tabular.Credential) bool { return c.Key == serviceAccount.Key }) {
+			serviceAccounts = append(serviceAccounts, serviceAccount)
+		}
+	}
+	return serviceAccounts, nil
+}
+
+// findServiceAccount matches a service account by its credential id, falling back to its name. Names aren't
+// guaranteed to be unique, so an ambiguous name is an error.
+func findServiceAccount(serviceAccounts []tabular.Credential, identifier string) (*tabular.Credential, error) {
+	for i, serviceAccount := range serviceAccounts {
+		if serviceAccount.Key == identifier || serviceAccount.Id == identifier {
+			return &serviceAccounts[i], nil
+		}
+	}
+
+	matches := internal.Filter(serviceAccounts, func(c tabular.Credential) bool { return c.Name == identifier })
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("could not find service account %s in org", identifier)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("found %d service accounts named %s, use the credential id instead", len(matches), identifier)
+	}
+}
@@ -0,0 +1,462 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/validators"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	pathpkg "path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	_ resource.Resource                   = &roleMembershipRuleResource{}
+	_ resource.ResourceWithConfigure      = &roleMembershipRuleResource{}
+	_ resource.ResourceWithModifyPlan     = &roleMembershipRuleResource{}
+	_ resource.ResourceWithValidateConfig = &roleMembershipRuleResource{}
+)
+
+type roleMembershipRuleResource struct {
+	client *util.Client
+}
+
+func NewRoleMembershipRuleResource() resource.Resource {
+	return &roleMembershipRuleResource{}
+}
+
+func (r *roleMembershipRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*util.Client)
+}
+
+type roleMembershipRuleModel struct {
+	Id              types.String `tfsdk:"id"`
+	RoleName        types.String `tfsdk:"role_name"`
+	EmailPatterns   types.Set    `tfsdk:"email_patterns"`
+	ExcludePatterns types.Set    `tfsdk:"exclude_patterns"`
+	PatternSyntax   types.String `tfsdk:"pattern_syntax"`
+	ResolvedMembers types.Set    `tfsdk:"resolved_members"`
+	ManagedMembers  types.Set    `tfsdk:"managed_members"`
+}
+
+const (
+	patternSyntaxGlob  = "glob"
+	patternSyntaxRegex = "regex"
+)
+
+func (r *roleMembershipRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_membership_rule"
+}
+
+func (r *roleMembershipRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Grant every org member whose email matches a pattern access to a role. Patterns are evaluated " +
+			"against the org's members when planning, so members who join the org are added on the next apply. " +
+			"The rule only removes the members it added itself. Members added to the role some other way are left " +
+			"alone, even if they match.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Terraform resource id",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				Description: "Role name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email_patterns": schema.SetAttribute{
+				Description: "Members whose email matches any of these patterns are added to the role, e.g. *@data.example.com. " +
+					"Matching is case-insensitive.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"exclude_patterns": schema.SetAttribute{
+				Description: "Members whose email matches any of these patterns are left out, even if they match email_patterns",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"pattern_syntax": schema.StringAttribute{
+				Description: "Either glob or regex. Glob patterns support * and ?, regular expressions must match the whole email. " +
+					"Defaults to glob.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.StringOneOfValidator{Values: []string{patternSyntaxGlob, patternSyntaxRegex}},
+				},
+			},
+			"resolved_members": schema.SetAttribute{
+				Description: "Emails of the org members matched by the rule",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"managed_members": schema.SetAttribute{
+				Description: "Emails of the members the rule added to the role, which it removes when they stop matching " +
+					"or the rule is destroyed",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *roleMembershipRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config roleMembershipRuleModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.EmailPatterns.IsUnknown() || config.ExcludePatterns.IsUnknown() || config.PatternSyntax.IsUnknown() {
+		return
+	}
+
+	_, err := config.rule(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("email_patterns"), "Invalid email pattern", err.Error())
+	}
+}
+
+func (r *roleMembershipRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to resolve when destroying, or before the provider has been configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan roleMembershipRuleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.EmailPatterns.IsUnknown() || plan.ExcludePatterns.IsUnknown() || plan.PatternSyntax.IsUnknown() {
+		return
+	}
+
+	rule, err := plan.rule(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("email_patterns"), "Invalid email pattern", err.Error())
+		return
+	}
+	orgMemberMap, err := r.client.V1.GetOrgMemberIdsMap()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("resolved_members"), rule.resolve(orgMemberMap))...)
+}
+
+func (r *roleMembershipRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleMembershipRuleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := state.rule(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid email pattern", err.Error())
+		return
+	}
+
+	roleName := state.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var managedMembers []string
+	resp.Diagnostics.Append(state.ManagedMembers.ElementsAs(ctx, &managedMembers, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolvedMembers := internal.Map(
+		internal.Filter(role.Members, func(m tabular.Member) bool { return rule.matches(m.Email) }),
+		func(m tabular.Member) string { return strings.ToLower(m.Email) },
+	)
+	// Members removed from the role outside of Terraform are no longer the rule's to remove
+	managedMembers = internal.Filter(managedMembers, func(email string) bool { return containsEmail(resolvedMembers, email) })
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_members"), resolvedMembers)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("managed_members"), managedMembers)...)
+}
+
+func (r *roleMembershipRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleMembershipRuleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgMemberMap, err := r.client.V1.GetOrgMemberIdsMap()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
+		return
+	}
+	resolvedMembers := r.resolvedMembers(ctx, &plan, orgMemberMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	roleName := plan.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+	if role == nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName)
+		return
+	}
+
+	_, toAdd := ruleMemberChanges(role.Members, nil, resolvedMembers)
+	addedMembers, err := r.addMembers(roleName, toAdd, orgMemberMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding role members", err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	plan.ManagedMembers, diags = types.SetValueFrom(ctx, types.StringType, addedMembers)
+	resp.Diagnostics.Append(diags...)
+	plan.Id = types.StringValue(roleName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *roleMembershipRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state roleMembershipRuleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var managedMembers []string
+	resp.Diagnostics.Append(state.ManagedMembers.ElementsAs(ctx, &managedMembers, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgMemberMap, err := r.client.V1.GetOrgMemberIdsMap()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch org members", err.Error())
+		return
+	}
+	planResolvedMembers := r.resolvedMembers(ctx, &plan, orgMemberMap, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := state.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+	if role == nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName)
+		return
+	}
+
+	// Removals come from the role itself, since members who left the org are no longer in the org member list
+	toRemove, toAdd := ruleMemberChanges(role.Members, managedMembers, planResolvedMembers)
+	err = r.client.V1.DeleteRoleMembers(roleName, internal.Map(toRemove, func(m tabular.Member) string { return m.Id }))
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing role members", err.Error())
+		return
+	}
+	managedMembers = internal.Filter(managedMembers, func(email string) bool {
+		return slices.IndexFunc(toRemove, func(m tabular.Member) bool { return strings.EqualFold(m.Email, email) }) < 0
+	})
+
+	addedMembers, err := r.addMembers(roleName, toAdd, orgMemberMap)
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding role members", err.Error())
+		// The removals went through, so record what the rule still manages
+		plan.ManagedMembers, _ = types.SetValueFrom(ctx, types.StringType, managedMembers)
+		plan.ResolvedMembers = state.ResolvedMembers
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	var diags diag.Diagnostics
+	plan.ManagedMembers, diags = types.SetValueFrom(ctx, types.StringType, append(managedMembers, addedMembers...))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *roleMembershipRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleMembershipRuleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var managedMembers []string
+	resp.Diagnostics.Append(state.ManagedMembers.ElementsAs(ctx, &managedMembers, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleName := state.RoleName.ValueString()
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return
+	}
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	toRemove, _ := ruleMemberChanges(role.Members, managedMembers, nil)
+	err = r.client.V1.DeleteRoleMembers(roleName, internal.Map(toRemove, func(m tabular.Member) string { return m.Id }))
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing role members", err.Error())
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// addMembers adds the emails to the role and returns the ones it added. Members who left the org since planning can't
+// be added; the next plan drops them.
+func (r *roleMembershipRuleResource) addMembers(roleName string, emails []string, orgMemberMap map[string]string) ([]string, error) {
+	added := internal.Filter(emails, func(email string) bool {
+		_, ok := orgMemberMap[strings.ToLower(email)]
+		return ok
+	})
+	err := r.client.V1.AddRoleMembers(roleName, nil, mapMemberEmailsToIds(added, orgMemberMap, func(string) {}))
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// ruleMemberChanges returns the role members to remove, the ones the rule added that no longer resolve, and the
+// resolved emails to add, the ones not in the role yet. Members already in the role are left to whoever added them.
+func ruleMemberChanges(roleMembers []tabular.Member, managedMembers, resolvedMembers []string) ([]tabular.Member, []string) {
+	toRemove := internal.Filter(roleMembers, func(m tabular.Member) bool {
+		return containsEmail(managedMembers, m.Email) && !containsEmail(resolvedMembers, m.Email)
+	})
+	toAdd := internal.Filter(resolvedMembers, func(email string) bool {
+		return slices.IndexFunc(roleMembers, func(m tabular.Member) bool { return strings.EqualFold(m.Email, email) }) < 0
+	})
+	return toRemove, toAdd
+}
+
+// resolvedMembers returns the planned members of the rule, resolving them now if the patterns weren't known
+// when planning
+func (r *roleMembershipRuleResource) resolvedMembers(ctx context.Context, plan *roleMembershipRuleModel, orgMemberMap map[string]string, diags *diag.Diagnostics) []string {
+	var resolvedMembers []string
+	if !plan.ResolvedMembers.IsUnknown() {
+		diags.Append(plan.ResolvedMembers.ElementsAs(ctx, &resolvedMembers, false)...)
+		return resolvedMembers
+	}
+
+	rule, err := plan.rule(ctx)
+	if err != nil {
+		diags.AddAttributeError(path.Root("email_patterns"), "Invalid email pattern", err.Error())
+		return nil
+	}
+	resolvedMembers = rule.resolve(orgMemberMap)
+	var d diag.Diagnostics
+	plan.ResolvedMembers, d = types.SetValueFrom(ctx, types.StringType, resolvedMembers)
+	diags.Append(d...)
+	return resolvedMembers
+}
+
+func (m roleMembershipRuleModel) rule(ctx context.Context) (*emailRule, error) {
+	var includes, excludes []string
+	diags := m.EmailPatterns.ElementsAs(ctx, &includes, true)
+	diags.Append(m.ExcludePatterns.ElementsAs(ctx, &excludes, true)...)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not read email patterns")
+	}
+	return newEmailRule(includes, excludes, m.PatternSyntax.ValueString())
+}
+
+// emailRule matches emails against include and exclude patterns, ignoring case
+type emailRule struct {
+	includes []func(string) bool
+	excludes []func(string) bool
+}
+
+func newEmailRule(includes, excludes []string, syntax string) (*emailRule, error) {
+	includeMatchers, err := compileEmailPatterns(includes, syntax)
+	if err != nil {
+		return nil, err
+	}
+	excludeMatchers, err := compileEmailPatterns(excludes, syntax)
+	if err != nil {
+		return nil, err
+	}
+	return &emailRule{includes: includeMatchers, excludes: excludeMatchers}, nil
+}
+
+func (e *emailRule) matches(email string) bool {
+	if email == "" {
+		return false
+	}
+	email = strings.ToLower(email)
+	matchesAny := func(matchers []func(string) bool) bool {
+		return slices.IndexFunc(matchers, func(match func(string) bool) bool { return match(email) }) >= 0
+	}
+	return matchesAny(e.includes) && !matchesAny(e.excludes)
+}
+
+// resolve returns the sorted emails in orgMemberMap matched by the rule
+func (e *emailRule) resolve(orgMemberMap map[string]string) []string {
+	resolved := make([]string, 0)
+	for email := range orgMemberMap {
+		if e.matches(email) {
+			resolved = append(resolved, email)
+		}
+	}
+	sort.Strings(resolved)
+	return resolved
+}
+
+func compileEmailPatterns(patterns []string, syntax string) ([]func(string) bool, error) {
+	matchers := make([]func(string) bool, 0, len(patterns))
+	for _, pattern := range patterns {
+		if syntax == patternSyntaxRegex {
+			re, err := regexp.Compile("^(?i:" + pattern + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %s: %w", pattern, err)
+			}
+			matchers = append(matchers, re.MatchString)
+			continue
+		}
+
+		glob := strings.ToLower(pattern)
+		if _, err := pathpkg.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %s: %w", pattern, err)
+		}
+		matchers = append(matchers, func(email string) bool {
+			matched, _ := pathpkg.Match(glob, email)
+			return matched
+		})
+	}
+	return matchers, nil
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func TestEmailRuleGlob(t *testing.T) {
+	rule, err := newEmailRule([]string{"*@data.example.com"}, []string{"contractor.*"}, patternSyntaxGlob)
+	assert.NoError(t, err)
+
+	assert.True(t, rule.matches("jane@data.example.com"))
+	assert.True(t, rule.matches("Jane@Data.Example.com"))
+	assert.False(t, rule.matches("contractor.bob@data.example.com"))
+	assert.False(t, rule.matches("jane@example.com"))
+	assert.False(t, rule.matches(""))
+
+	orgMembers := map[string]string{
+		"jane@data.example.com":           "1",
+		"contractor.bob@data.example.com": "2",
+		"amy@data.example.com":            "3",
+		"jane@example.com":                "4",
+	}
+	assert.Equal(t, []string{"amy@data.example.com", "jane@data.example.com"}, rule.resolve(orgMembers))
+}
+
+func TestEmailRuleRegex(t *testing.T) {
+	rule, err := newEmailRule([]string{`[a-z]+@(data|ml)\.example\.com`}, nil, patternSyntaxRegex)
+	assert.NoError(t, err)
+
+	assert.True(t, rule.matches("jane@ml.example.com"))
+	// Regular expressions must match the whole email
+	assert.False(t, rule.matches("jane@ml.example.com.evil.org"))
+	assert.False(t, rule.matches("jane2@data.example.com"))
+
+	_, err = newEmailRule([]string{"("}, nil, patternSyntaxRegex)
+	assert.Error(t, err)
+	_, err = newEmailRule([]string{"[a-"}, nil, patternSyntaxGlob)
+	assert.Error(t, err)
+}
+
+func TestRuleMemberChanges(t *testing.T) {
+	roleMembers := []tabular.Member{
+		{Id: "1", Email: "jane@data.example.com"},
+		{Id: "2", Email: "Amy@data.example.com"},
+		{Id: "3", Email: "bob@data.example.com"},
+	}
+
+	// amy was added by the rule and stopped matching. bob was added some other way, so he stays even though he no
+	// longer matches, and jane is already in the role.
+	toRemove, toAdd := ruleMemberChanges(roleMembers, []string{"amy@data.example.com"}, []string{"jane@data.example.com", "new@data.example.com"})
+	assert.Equal(t, []tabular.Member{roleMembers[1]}, toRemove)
+	assert.Equal(t, []string{"new@data.example.com"}, toAdd)
+
+	// Destroying the rule only removes the members it added
+	toRemove, toAdd = ruleMemberChanges(roleMembers, []string{"amy@data.example.com"}, nil)
+	assert.Equal(t, []tabular.Member{roleMembers[1]}, toRemove)
+	assert.Empty(t, toAdd)
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func TestFindServiceAccount(t *testing.T) {
+	serviceAccounts := []tabular.Credential{
+		{Id: "c1", Key: "k1", MemberId: "m1", Name: "ingest"},
+		{Id: "c2", Key: "k2", MemberId: "m2", Name: "reporting"},
+		{Id: "c3", Key: "k3", MemberId: "m3", Name: "reporting"},
+	}
+
+	sa, err := findServiceAccount(serviceAccounts, "k2")
+	assert.NoError(t, err)
+	assert.Equal(t, "m2", sa.MemberId)
+
+	sa, err = findServiceAccount(serviceAccounts, "c3")
+	assert.NoError(t, err)
+	assert.Equal(t, "m3", sa.MemberId)
+
+	sa, err = findServiceAccount(serviceAccounts, "ingest")
+	assert.NoError(t, err)
+	assert.Equal(t, "m1", sa.MemberId)
+
+	_, err = findServiceAccount(serviceAccounts, "reporting")
+	assert.ErrorContains(t, err, "found 2 service accounts named reporting")
+
+	_, err = findServiceAccount(serviceAccounts, "missing")
+	assert.Error(t, err)
+}
+
+func TestConfiguredEmail(t *testing.T) {
+	configured := []string{"Jane.Doe@Example.com", "john@example.com"}
+
+	assert.Equal(t, "Jane.Doe@Example.com", configuredEmail(configured, "jane.doe@example.com"))
+	assert.Equal(t, "john@example.com", configuredEmail(configured, "john@example.com"))
+	assert.Equal(t, "new@example.com", configuredEmail(configured, "new@example.com"))
+	assert.True(t, containsEmail(configured, "JOHN@example.com"))
+	assert.False(t, containsEmail(configured, "new@example.com"))
+}
+
+func TestMissingMemberHandler(t *testing.T) {
+	var diags diag.Diagnostics
+	pending := make([]string, 0)
+	orgMembers := map[string]string{"jane@example.com": "1"}
+
+	ids := mapMemberEmailsToIds([]string{"Jane@example.com", "new@example.com"}, orgMembers,
+		missingMemberHandler(&diags, "members", true, &pending))
+	assert.Equal(t, []string{"1"}, ids)
+	assert.Equal(t, []string{"new@example.com"}, pending)
+	assert.False(t, diags.HasError())
+
+	mapMemberEmailsToIds([]string{"new@example.com"}, orgMembers, missingMemberHandler(&diags, "members", false, &pending))
+	assert.True(t, diags.HasError())
+	assert.Len(t, pending, 1)
+}
+
+func TestRoleMemberChanges(t *testing.T) {
+	// jane is demoted, john is promoted, joe is dropped and jill is new
+	toRemove, adminToAdd, toAdd := roleMemberChanges(
+		[]string{"jane"}, []string{"john", "joe"},
+		[]string{"john"}, []string{"jane", "jill"},
+	)
+	assert.ElementsMatch(t, []string{"jane", "john", "joe"}, toRemove)
+	assert.Equal(t, []string{"john"}, adminToAdd)
+	assert.ElementsMatch(t, []string{"jane", "jill"}, toAdd)
+
+	toRemove, adminToAdd, toAdd = roleMemberChanges([]string{"jane"}, []string{"john"}, []string{"jane"}, []string{"john"})
+	assert.Empty(t, toRemove)
+	assert.Empty(t, adminToAdd)
+	assert.Empty(t, toAdd)
+}
+
+func TestUnmanagedRoleMemberIds(t *testing.T) {
+	roleMembers := []tabular.Member{
+		{Id: "1", Email: "jane@example.com", WithAdmin: true},
+		{Id: "2", Email: "john@example.com"},
+		{Id: "3", Email: "other@example.com"},
+	}
+
+	// Authoritative creates and updates prune everyone the plan doesn't list
+	assert.Equal(t, []string{"3"}, unmanagedRoleMemberIds(roleMembers, []string{"1", "2"}))
+	assert.Empty(t, unmanagedRoleMemberIds(roleMembers, []string{"1", "2", "3"}))
+	assert.Equal(t, []string{"1", "2", "3"}, unmanagedRoleMemberIds(roleMembers, nil))
+
+	// Additive membership never prunes
+	r := &roleMembershipResource{}
+	assert.NoError(t, r.removeUnmanagedMembers(roleMembershipModel{Mode: types.StringValue(roleMembershipModeAdditive)}, nil))
+}
+
+func TestManagedRoleMemberIds(t *testing.T) {
+	roleMembers := []tabular.Member{
+		{Id: "1", Email: "Jane@example.com", WithAdmin: true},
+		{Id: "2", Email: "john@example.com"},
+		{Id: "3", Email: "other@example.com"},
+		{Id: "4", Email: "pipeline@service-account"},
+	}
+
+	// Deletes only remove members recorded in state, whichever mode the resource is in
+	assert.Equal(t, []string{"1", "2", "4"}, managedRoleMemberIds(roleMembers, []string{"jane@example.com", "john@example.com"}, []string{"4"}))
+	assert.Empty(t, managedRoleMemberIds(roleMembers, nil, nil))
+	assert.Equal(t, []string{"2"}, managedRoleMemberIds(roleMembers, []string{"john@example.com", "left@example.com"}, []string{"9"}))
+}
+
+func TestSplitServiceAccountMembers(t *testing.T) {
+	members := []tabular.Member{
+		{Id: "1", Email: "jane@example.com"},
+		{Id: "m1"},
+		{Id: "m2"},
+		{Id: "m3"},
+	}
+	serviceAccounts := map[string]tabular.Credential{
+		"m1": {Key: "k1", MemberId: "m1", Name: "ingest"},
+		"m2": {Key: "k2", MemberId: "m2", Name: "reporting"},
+		"m3": {Key: "k3", MemberId: "m3", Name: "other"},
+	}
+	configured := map[string]string{"m1": "ingest"}
+
+	// m2 is configured in member_ids, so it stays there instead of moving to service_accounts
+	roleMembers, serviceAccountMembers := splitServiceAccountMembers(members, serviceAccounts, configured, []string{"m2"}, false)
+	assert.Equal(t, []string{"1", "m2"}, internal.Map(roleMembers, func(m tabular.Member) string { return m.Id }))
+	assert.Equal(t, []string{"ingest", "k3"}, serviceAccountMembers)
+
+	_, serviceAccountMembers = splitServiceAccountMembers(members, serviceAccounts, configured, []string{"m2"}, true)
+	assert.Equal(t, []string{"ingest"}, serviceAccountMembers)
+}
+
+// newTestClient returns a client whose V1 and V2 APIs are both served by handler
+func newTestClient(t *testing.T, handler http.Handler) *util.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := tabularv2.NewConfiguration()
+	config.HTTPClient = server.Client()
+	config.Servers = []tabularv2.ServerConfiguration{{URL: server.URL}}
+	organizationId := "org"
+	return &util.Client{
+		V1:             &tabular.Client{Endpoint: server.URL, HTTPClient: server.Client()},
+		V2:             tabularv2.NewAPIClient(config),
+		OrganizationId: &organizationId,
+	}
+}
+
+func TestLookupServiceAccounts(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/organizations/org/iam/credentials/k1":
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "c1", "key": "k1", "memberId": "m1", "name": "ingest", "type": "SERVICE"})
+		case "/ws/v1/iam/credentials":
+			_ = json.NewEncoder(w).Encode([]map[string]string{
+				{"id": "c1", "key": "k1", "memberId": "m1", "name": "ingest", "type": "SERVICE"},
+				{"id": "c2", "key": "k2", "memberId": "m2", "name": "reporting", "type": "SERVICE"},
+				{"id": "c3", "key": "k3", "memberId": "m3", "name": "jane", "type": "USER"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	// Memberships without service accounts never ask for them
+	serviceAccounts, err := lookupServiceAccounts(context.Background(), client, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, serviceAccounts)
+	assert.Empty(t, requests)
+
+	// Credential keys are fetched directly
+	serviceAccounts, err = lookupServiceAccounts(context.Background(), client, []string{"k1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []tabular.Credential{{Id: "c1", Key: "k1", MemberId: "m1", Name: "ingest", Type: "SERVICE"}}, serviceAccounts)
+	assert.Equal(t, []string{"/v1/organizations/org/iam/credentials/k1"}, requests)
+
+	// Names fall back to listing the org's service accounts
+	requests = nil
+	serviceAccounts, err = lookupServiceAccounts(context.Background(), client, []string{"k1", "reporting"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"k1", "k2"}, internal.Map(serviceAccounts, func(c tabular.Credential) string { return c.Key }))
+	assert.Equal(t, "/ws/v1/iam/credentials", requests[len(requests)-1])
+	sa, err := findServiceAccount(serviceAccounts, "reporting")
+	assert.NoError(t, err)
+	assert.Equal(t, "m2", sa.MemberId)
+}
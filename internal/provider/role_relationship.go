@@ -2,19 +2,24 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
 	"strings"
 )
 
 var (
-	_ resource.Resource                = &roleRelationshipResource{}
-	_ resource.ResourceWithConfigure   = &roleRelationshipResource{}
-	_ resource.ResourceWithImportState = &roleRelationshipResource{}
+	_ resource.Resource                 = &roleRelationshipResource{}
+	_ resource.ResourceWithConfigure    = &roleRelationshipResource{}
+	_ resource.ResourceWithImportState  = &roleRelationshipResource{}
+	_ resource.ResourceWithUpgradeState = &roleRelationshipResource{}
 )
 
 type roleRelationshipResource struct {
@@ -33,8 +38,16 @@ func (r *roleRelationshipResource) Configure(ctx context.Context, req resource.C
 	r.client = req.ProviderData.(*util.Client)
 }
 
+type roleRelationshipModelV0 struct {
+	ParentRoleName types.String `tfsdk:"parent_role_name"`
+	ChildRoleName  types.String `tfsdk:"child_role_name"`
+}
+
 type roleRelationshipModel struct {
+	Id             types.String `tfsdk:"id"`
+	ParentRoleId   types.String `tfsdk:"parent_role_id"`
 	ParentRoleName types.String `tfsdk:"parent_role_name"`
+	ChildRoleId    types.String `tfsdk:"child_role_id"`
 	ChildRoleName  types.String `tfsdk:"child_role_name"`
 }
 
@@ -44,22 +57,97 @@ func (r *roleRelationshipResource) Metadata(ctx context.Context, req resource.Me
 
 func (r *roleRelationshipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Relationship between two roles",
+		Version: 1,
+		Description: "Relationship between two roles. The relationship is tracked by role ID, so renaming either role " +
+			"updates it in place.",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Terraform resource id (parentRoleId/childRoleId)",
+				Computed:    true,
+			},
+			"parent_role_id": schema.StringAttribute{
+				Description: "Parent role ID. When set, the relationship is only created if parent_role_name has this ID.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
 			"parent_role_name": schema.StringAttribute{
 				Description: "Parent role name",
 				Required:    true,
+			},
+			"child_role_id": schema.StringAttribute{
+				Description: "Child role ID. When set, the relationship is only created if child_role_name has this ID.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"child_role_name": schema.StringAttribute{
 				Description: "Child role name",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
+
+func (r *roleRelationshipResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Add the parent and child role ids to role_relationship v0 resource
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"parent_role_name": schema.StringAttribute{
+						Description: "Parent role name",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"child_role_name": schema.StringAttribute{
+						Description: "Child role name",
+						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
 				},
 			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorStateData roleRelationshipModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorStateData)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				parentRoleName := priorStateData.ParentRoleName.ValueString()
+				childRoleName := priorStateData.ChildRoleName.ValueString()
+				parentRole, err := r.client.V1.GetRole(parentRoleName)
+				if err != nil || parentRole == nil {
+					resp.Diagnostics.AddError("Unable to fetch role id", "Could not fetch role "+parentRoleName)
+					return
+				}
+				child := findChildRole(parentRole.Children, "", childRoleName)
+				if child == nil {
+					resp.Diagnostics.AddError(
+						"Role relationship not found",
+						"Could not find role relationship between "+parentRoleName+" and "+childRoleName,
+					)
+					return
+				}
+
+				upgradedStateData := roleRelationshipModel{
+					ParentRoleName: priorStateData.ParentRoleName,
+					ChildRoleName:  priorStateData.ChildRoleName,
+				}
+				upgradedStateData.setIds(parentRole.Id, child.Id)
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedStateData)...)
+			},
 		},
 	}
 }
@@ -68,11 +156,36 @@ func (r *roleRelationshipResource) ImportState(ctx context.Context, req resource
 	parts := strings.SplitN(req.ID, "/", 2)
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError("Invalid role relationship specifier", "Expected two part value, split by a /")
+		return
 	}
+
+	// Relationships can be imported by parentId/childId or by parentName/childName
+	roles, err := r.client.V1.ListRoles()
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing roles", err.Error())
+		return
+	}
+	parentRoleName, childRoleId, childRoleName := parts[0], "", parts[1]
+	if parent := findRoleById(roles, parts[0]); parent != nil {
+		parentRoleName, childRoleId, childRoleName = parent.Name, parts[1], ""
+	}
+
+	parentRole, err := r.client.V1.GetRole(parentRoleName)
+	if err != nil || parentRole == nil {
+		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+parts[0])
+		return
+	}
+	child := findChildRole(parentRole.Children, childRoleId, childRoleName)
+	if child == nil {
+		resp.Diagnostics.AddError("Role relationship not found", "Could not find role relationship between "+parts[0]+" and "+parts[1])
+		return
+	}
+
 	state := roleRelationshipModel{
-		ParentRoleName: types.StringValue(parts[0]),
-		ChildRoleName:  types.StringValue(parts[1]),
+		ParentRoleName: types.StringValue(parentRole.Name),
+		ChildRoleName:  types.StringValue(child.Name),
 	}
+	state.setIds(parentRole.Id, child.Id)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -87,24 +200,54 @@ func (r *roleRelationshipResource) Read(ctx context.Context, req resource.ReadRe
 
 	parentRoleName := state.ParentRoleName.ValueString()
 	role, err := r.client.V1.GetRole(parentRoleName)
-	if role == nil {
-		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+parentRoleName)
-		return
-	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+parentRoleName+": "+err.Error())
 		return
 	}
-	found := false
-	childRoleName := state.ChildRoleName.ValueString()
-	for _, child := range role.Children {
-		if child.Name == childRoleName {
-			found = true
+	if state.ParentRoleId.IsNull() {
+		if role == nil {
+			resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+parentRoleName)
+			return
+		}
+	} else if role == nil || role.Id != state.ParentRoleId.ValueString() {
+		// The parent was renamed, possibly with another role taking its old name, or deleted
+		role, err = r.getRoleById(state.ParentRoleId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error fetching role", "Could not fetch role "+state.ParentRoleId.ValueString()+": "+err.Error())
+			return
+		}
+		if role == nil {
+			resp.State.RemoveResource(ctx)
+			return
 		}
+		state.ParentRoleName = types.StringValue(role.Name)
+	}
+
+	child := findChildRole(role.Children, state.ChildRoleId.ValueString(), state.ChildRoleName.ValueString())
+	if child == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Renaming the child role shows up here, since its new name is reported by the parent
+	state.ChildRoleName = types.StringValue(child.Name)
+	state.setIds(role.Id, child.Id)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// getRoleById looks a role up by id, returning nil when no role has it. Roles can only be fetched by name, so the
+// name comes from the role list.
+func (r *roleRelationshipResource) getRoleById(roleId string) (*tabular.Role, error) {
+	roles, err := r.client.V1.ListRoles()
+	if err != nil {
+		return nil, err
 	}
-	if !found {
-		resp.Diagnostics.AddError("Role relationship not found", "Could not find role relationship between "+parentRoleName+" and "+childRoleName)
+	role := findRoleById(roles, roleId)
+	if role == nil {
+		return nil, nil
 	}
+	return r.client.V1.GetRole(role.Name)
 }
 
 func (r *roleRelationshipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -114,12 +257,18 @@ func (r *roleRelationshipResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	parentRoleId, childRoleId := r.resolveRoleIds(plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.V1.AddRoleRelation(plan.ParentRoleName.ValueString(), plan.ChildRoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating role relation", err.Error())
 		return
 	}
 
+	plan.setIds(parentRoleId, childRoleId)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -127,7 +276,34 @@ func (r *roleRelationshipResource) Create(ctx context.Context, req resource.Crea
 }
 
 func (r *roleRelationshipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Role relation unsupported", "It shouldn't be possible to cause an update")
+	var plan, state roleRelationshipModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parentRoleId, childRoleId := r.resolveRoleIds(plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A renamed role keeps its id, and the relationship with it. Only a different role needs a new relationship.
+	if parentRoleId != state.ParentRoleId.ValueString() || childRoleId != state.ChildRoleId.ValueString() {
+		err := r.client.V1.AddRoleRelation(plan.ParentRoleName.ValueString(), plan.ChildRoleName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating role relation", err.Error())
+			return
+		}
+		err = r.client.V1.DeleteRoleRelation(state.ParentRoleName.ValueString(), state.ChildRoleName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error deleting role relation", err.Error())
+			return
+		}
+	}
+
+	plan.setIds(parentRoleId, childRoleId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func (r *roleRelationshipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -145,3 +321,58 @@ func (r *roleRelationshipResource) Delete(ctx context.Context, req resource.Dele
 
 	resp.State.RemoveResource(ctx)
 }
+
+// resolveRoleIds looks up the ids of the planned parent and child roles, checking them against the configured ids
+func (r *roleRelationshipResource) resolveRoleIds(plan roleRelationshipModel, diags *diag.Diagnostics) (string, string) {
+	parentRoleId := r.resolveRoleId(plan.ParentRoleName.ValueString(), plan.ParentRoleId, path.Root("parent_role_id"), diags)
+	childRoleId := r.resolveRoleId(plan.ChildRoleName.ValueString(), plan.ChildRoleId, path.Root("child_role_id"), diags)
+	return parentRoleId, childRoleId
+}
+
+func (r *roleRelationshipResource) resolveRoleId(roleName string, configuredId types.String, attribute path.Path, diags *diag.Diagnostics) string {
+	role, err := r.client.V1.GetRole(roleName)
+	if err != nil {
+		diags.AddError("Error fetching role", "Could not fetch role "+roleName+": "+err.Error())
+		return ""
+	}
+	if role == nil {
+		diags.AddError("Error fetching role", "Could not fetch role "+roleName)
+		return ""
+	}
+	if !configuredId.IsNull() && !configuredId.IsUnknown() && configuredId.ValueString() != role.Id {
+		diags.AddAttributeError(
+			attribute,
+			"Role id mismatch",
+			fmt.Sprintf("Role %s has id %s, not %s", roleName, role.Id, configuredId.ValueString()),
+		)
+	}
+	return role.Id
+}
+
+func (m *roleRelationshipModel) setIds(parentRoleId, childRoleId string) {
+	m.Id = types.StringValue(fmt.Sprintf("%s/%s", parentRoleId, childRoleId))
+	m.ParentRoleId = types.StringValue(parentRoleId)
+	m.ChildRoleId = types.StringValue(childRoleId)
+}
+
+// findChildRole looks a child up by id, falling back to name when the id isn't known
+func findChildRole(children []tabular.Role, childRoleId, childRoleName string) *tabular.Role {
+	for i, child := range children {
+		if childRoleId != "" && child.Id == childRoleId {
+			return &children[i]
+		}
+		if childRoleId == "" && child.Name == childRoleName {
+			return &children[i]
+		}
+	}
+	return nil
+}
+
+func findRoleById(roles []tabular.Role, roleId string) *tabular.Role {
+	for i, role := range roles {
+		if role.Id == roleId {
+			return &roles[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func TestFindChildRole(t *testing.T) {
+	children := []tabular.Role{
+		{Id: "1", Name: "analysts"},
+		{Id: "2", Name: "engineers"},
+	}
+
+	assert.Equal(t, "2", findChildRole(children, "", "engineers").Id)
+	// A known id finds the child even after it was renamed
+	assert.Equal(t, "analysts", findChildRole(children, "1", "old analysts").Name)
+	assert.Nil(t, findChildRole(children, "3", "analysts"))
+	assert.Nil(t, findChildRole(children, "", "interns"))
+}
+
+func TestFindRoleById(t *testing.T) {
+	roles := []tabular.Role{
+		{Id: "1", Name: "analysts"},
+		{Id: "2", Name: "engineers"},
+	}
+
+	assert.Equal(t, "engineers", findRoleById(roles, "2").Name)
+	assert.Nil(t, findRoleById(roles, "engineers"))
+}
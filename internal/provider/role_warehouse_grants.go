@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -96,23 +97,12 @@ func (r *roleWarehouseGrantsResource) Read(ctx context.Context, req resource.Rea
 	warehouseId := state.WarehouseId.ValueString()
 	roleId := state.RoleId.ValueString()
 
-	warehouseGrants, _, err := r.client.V2.DefaultAPI.ListWarehouseRoleGrantsForRole(ctx, *r.client.OrganizationId, warehouseId, roleId).Execute()
+	privileges, privilegesWithGrant, err := r.readGrants(ctx, warehouseId, roleId)
 	if err != nil {
 		resp.Diagnostics.AddError("Error getting role grants", "Could not get grants for warehouse "+err.Error())
 		return
 	}
 
-	var privileges []string
-	var privilegesWithGrant []string
-
-	for _, grant := range warehouseGrants.Authorizations {
-		if *grant.WithGrant {
-			privilegesWithGrant = append(privilegesWithGrant, *grant.Privilege)
-		} else {
-			privileges = append(privileges, *grant.Privilege)
-		}
-	}
-
 	state.Privileges, diags = types.SetValueFrom(ctx, types.StringType, privileges)
 	resp.Diagnostics.Append(diags...)
 	state.PrivilegesWithGrant, diags = types.SetValueFrom(ctx, types.StringType, privilegesWithGrant)
@@ -167,6 +157,7 @@ func (r *roleWarehouseGrantsResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	var diags diag.Diagnostics
 	warehouseId := plan.WarehouseId.ValueString()
 	roleId := plan.RoleId.ValueString()
 
@@ -178,6 +169,12 @@ func (r *roleWarehouseGrantsResource) Update(ctx context.Context, req resource.U
 	resp.Diagnostics.Append(state.Privileges.ElementsAs(ctx, &statePlanPrivileges, false)...)
 	resp.Diagnostics.Append(state.PrivilegesWithGrant.ElementsAs(ctx, &statePlanPrivilegesWithGrant, false)...)
 
+	// Tracks the grants applied so far, so state only ever records what the role has actually been given
+	applied := plan
+	applied.Id = types.StringValue(fmt.Sprintf("%s/%s", warehouseId, roleId))
+	applied.Privileges = state.Privileges
+	applied.PrivilegesWithGrant = state.PrivilegesWithGrant
+
 	// Remove privileges
 	privilegesToRemove := internal.Difference(statePlanPrivileges, planPrivileges)
 	privilegesToRemoveWithGrant := internal.Difference(statePlanPrivilegesWithGrant, planPrivilegesWithGrant)
@@ -191,11 +188,19 @@ func (r *roleWarehouseGrantsResource) Update(ctx context.Context, req resource.U
 			Execute()
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to revoke grant", "Unable to revoke grant"+err.Error())
+			r.refreshState(ctx, applied, resp)
 			return
 		}
-	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		applied.Privileges, diags = types.SetValueFrom(ctx, types.StringType, internal.Difference(statePlanPrivileges, privilegesToRemove))
+		resp.Diagnostics.Append(diags...)
+		applied.PrivilegesWithGrant, diags = types.SetValueFrom(ctx, types.StringType, internal.Difference(statePlanPrivilegesWithGrant, privilegesToRemoveWithGrant))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, applied)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Add privileges
 	privilegesToAdd := internal.Difference(planPrivileges, statePlanPrivileges)
@@ -210,6 +215,7 @@ func (r *roleWarehouseGrantsResource) Update(ctx context.Context, req resource.U
 			Execute()
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to create grant", "Unable to create grant"+err.Error())
+			r.refreshState(ctx, applied, resp)
 			return
 		}
 	}
@@ -253,6 +259,41 @@ func (r *roleWarehouseGrantsResource) Delete(ctx context.Context, req resource.D
 	resp.State.RemoveResource(ctx)
 }
 
+// readGrants fetches the privileges the role currently holds on the warehouse, split by grant option.
+func (r *roleWarehouseGrantsResource) readGrants(ctx context.Context, warehouseId, roleId string) (privileges, privilegesWithGrant []string, err error) {
+	retryFunc := util.RetryResourceResponse[*tabular.GetRoleWarehouseGrantsResponse]
+	warehouseGrants, _, err := retryFunc(r.client.V2.DefaultAPI.ListWarehouseRoleGrantsForRole(ctx, *r.client.OrganizationId, warehouseId, roleId).Execute)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, grant := range warehouseGrants.Authorizations {
+		if *grant.WithGrant {
+			privilegesWithGrant = append(privilegesWithGrant, *grant.Privilege)
+		} else {
+			privileges = append(privileges, *grant.Privilege)
+		}
+	}
+	return privileges, privilegesWithGrant, nil
+}
+
+// refreshState is used when an update fails part way through. It persists the grants the role actually holds so the
+// next plan shows any remaining drift. If they can't be fetched, the applied state passed in is kept instead.
+func (r *roleWarehouseGrantsResource) refreshState(ctx context.Context, state roleWarehouseGrantsResourceModel, resp *resource.UpdateResponse) {
+	privileges, privilegesWithGrant, err := r.readGrants(ctx, state.WarehouseId.ValueString(), state.RoleId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to refresh grants after failed update", err.Error())
+	} else {
+		var diags diag.Diagnostics
+		state.Privileges, diags = types.SetValueFrom(ctx, types.StringType, privileges)
+		resp.Diagnostics.Append(diags...)
+		state.PrivilegesWithGrant, diags = types.SetValueFrom(ctx, types.StringType, privilegesWithGrant)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
 func warehousePrivilegeRequest(privileges []string, withGrant bool, roleId string) []tabular.RoleWarehouseGrantRequest {
 	var roleWarehouseGrantRequest []tabular.RoleWarehouseGrantRequest
 	for _, privilege := range privileges {
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"sort"
+)
+
+var _ datasource.DataSource = &RolesDataSource{}
+var _ datasource.DataSourceWithConfigure = &RolesDataSource{}
+
+func NewRolesDataSource() datasource.DataSource {
+	return &RolesDataSource{}
+}
+
+type RolesDataSource struct {
+	client *util.Client
+}
+
+type RolesDataSourceModel struct {
+	NamePrefix types.String       `tfsdk:"name_prefix"`
+	NameRegex  types.String       `tfsdk:"name_regex"`
+	Names      types.List         `tfsdk:"names"`
+	Roles      []RoleSummaryModel `tfsdk:"roles"`
+}
+
+type RoleSummaryModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *RolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *RolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("roles")
+	attributes["names"] = schema.ListAttribute{
+		MarkdownDescription: "Role names, sorted",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+	attributes["roles"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Roles, sorted by name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "ID",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Role Name",
+					Computed:            true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the roles in the organization",
+		Attributes:          attributes,
+	}
+}
+
+func (d *RolesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RolesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newNameFilter(data.NamePrefix, data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name filter", err.Error())
+		return
+	}
+
+	roles, err := d.client.V1.ListRoles()
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing roles", err.Error())
+		return
+	}
+	roles = internal.Filter(roles, func(r tabular.Role) bool { return filter.matches(r.Name) })
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+	data.Roles = internal.Map(roles, func(r tabular.Role) RoleSummaryModel {
+		return RoleSummaryModel{Id: types.StringValue(r.Id), Name: types.StringValue(r.Name)}
+	})
+	names, diags := types.ListValueFrom(ctx, types.StringType, internal.Map(roles, func(r tabular.Role) string { return r.Name }))
+	resp.Diagnostics.Append(diags...)
+	data.Names = names
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
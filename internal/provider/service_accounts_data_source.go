@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"sort"
+)
+
+var _ datasource.DataSource = &ServiceAccountsDataSource{}
+var _ datasource.DataSourceWithConfigure = &ServiceAccountsDataSource{}
+
+func NewServiceAccountsDataSource() datasource.DataSource {
+	return &ServiceAccountsDataSource{}
+}
+
+type ServiceAccountsDataSource struct {
+	client *util.Client
+}
+
+type ServiceAccountsDataSourceModel struct {
+	NamePrefix      types.String                 `tfsdk:"name_prefix"`
+	NameRegex       types.String                 `tfsdk:"name_regex"`
+	ServiceAccounts []ServiceAccountSummaryModel `tfsdk:"service_accounts"`
+}
+
+type ServiceAccountSummaryModel struct {
+	Id       types.String `tfsdk:"id"`
+	Key      types.String `tfsdk:"key"`
+	Name     types.String `tfsdk:"name"`
+	MemberId types.String `tfsdk:"member_id"`
+	RoleId   types.String `tfsdk:"role_id"`
+}
+
+func (d *ServiceAccountsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_accounts"
+}
+
+func (d *ServiceAccountsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("service accounts")
+	attributes["service_accounts"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Service accounts, sorted by name and then key. Credential secrets are never returned.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Credential ID",
+					Computed:            true,
+				},
+				"key": schema.StringAttribute{
+					MarkdownDescription: "Credential Key",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Service Account Name",
+					Computed:            true,
+				},
+				"member_id": schema.StringAttribute{
+					MarkdownDescription: "Member ID the service account is added to roles as",
+					Computed:            true,
+				},
+				"role_id": schema.StringAttribute{
+					MarkdownDescription: "ID of the service account's default role",
+					Computed:            true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the service accounts in the organization",
+		Attributes:          attributes,
+	}
+}
+
+func (d *ServiceAccountsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ServiceAccountsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServiceAccountsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newNameFilter(data.NamePrefix, data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name filter", err.Error())
+		return
+	}
+
+	serviceAccounts, err := d.client.V1.GetServiceAccounts()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to fetch service accounts", err.Error())
+		return
+	}
+	serviceAccounts = internal.Filter(serviceAccounts, func(c tabular.Credential) bool { return filter.matches(c.Name) })
+	sort.Slice(serviceAccounts, func(i, j int) bool {
+		if serviceAccounts[i].Name != serviceAccounts[j].Name {
+			return serviceAccounts[i].Name < serviceAccounts[j].Name
+		}
+		return serviceAccounts[i].Key < serviceAccounts[j].Key
+	})
+
+	data.ServiceAccounts = internal.Map(serviceAccounts, func(c tabular.Credential) ServiceAccountSummaryModel {
+		return ServiceAccountSummaryModel{
+			Id:       types.StringValue(c.Id),
+			Key:      types.StringValue(c.Key),
+			Name:     types.StringValue(c.Name),
+			MemberId: types.StringValue(c.MemberId),
+			RoleId:   types.StringValue(c.RoleId),
+		}
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"sort"
+)
+
+var _ datasource.DataSource = &StorageProfilesDataSource{}
+var _ datasource.DataSourceWithConfigure = &StorageProfilesDataSource{}
+
+func NewStorageProfilesDataSource() datasource.DataSource {
+	return &StorageProfilesDataSource{}
+}
+
+type StorageProfilesDataSource struct {
+	client *util.Client
+}
+
+type StorageProfilesDataSourceModel struct {
+	NamePrefix      types.String                 `tfsdk:"name_prefix"`
+	NameRegex       types.String                 `tfsdk:"name_regex"`
+	StorageProfiles []StorageProfileSummaryModel `tfsdk:"storage_profiles"`
+}
+
+type StorageProfileSummaryModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	AccountId types.String `tfsdk:"account_id"`
+	Region    types.String `tfsdk:"region"`
+	RoleArn   types.String `tfsdk:"role_arn"`
+}
+
+func (d *StorageProfilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_profiles"
+}
+
+func (d *StorageProfilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("storage profiles")
+	attributes["storage_profiles"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Storage profiles, sorted by bucket name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Storage Profile ID",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Storage Profile bucket name",
+					Computed:            true,
+				},
+				"account_id": schema.StringAttribute{
+					MarkdownDescription: "Storage Profile AWS Account ID",
+					Computed:            true,
+				},
+				"region": schema.StringAttribute{
+					MarkdownDescription: "Storage Profile region",
+					Computed:            true,
+				},
+				"role_arn": schema.StringAttribute{
+					MarkdownDescription: "Storage Profile AWS Role Arn",
+					Computed:            true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the storage profiles used by the organization's warehouses. The API can't list " +
+			"storage profiles directly, so profiles no warehouse uses aren't returned.",
+		Attributes: attributes,
+	}
+}
+
+func (d *StorageProfilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *StorageProfilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data StorageProfilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newNameFilter(data.NamePrefix, data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name filter", err.Error())
+		return
+	}
+
+	listFunc := util.RetryResourceResponse[*tabularv2.ListWarehouseResponse]
+	listResp, _, err := listFunc(d.client.V2.DefaultAPI.ListWarehouses(ctx, *d.client.OrganizationId).Execute)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing warehouses", err.Error())
+		return
+	}
+
+	seen := make(map[string]bool)
+	data.StorageProfiles = make([]StorageProfileSummaryModel, 0)
+	for _, warehouse := range listResp.Warehouses {
+		storageProfileId := warehouse.GetStorageProfile()
+		if storageProfileId == "" || seen[storageProfileId] {
+			continue
+		}
+		seen[storageProfileId] = true
+
+		getFunc := util.RetryResourceResponse[*tabularv2.GetStorageProfileResponse]
+		storageProfile, _, err := getFunc(d.client.V2.DefaultAPI.GetStorageProfile(ctx, *d.client.OrganizationId, storageProfileId).Execute)
+		if err != nil {
+			resp.Diagnostics.AddError("Error fetching storage profile", "Could not fetch storage profile "+storageProfileId+": "+err.Error())
+			return
+		}
+		if !filter.matches(storageProfile.GetBucket()) {
+			continue
+		}
+		data.StorageProfiles = append(data.StorageProfiles, StorageProfileSummaryModel{
+			Id:        types.StringValue(storageProfile.GetId()),
+			Name:      types.StringValue(storageProfile.GetBucket()),
+			AccountId: types.StringValue(storageProfile.GetAccountId()),
+			Region:    types.StringValue(storageProfile.GetRegion()),
+			RoleArn:   types.StringValue(storageProfile.GetRoleArn()),
+		})
+	}
+	sort.Slice(data.StorageProfiles, func(i, j int) bool {
+		return data.StorageProfiles[i].Name.ValueString() < data.StorageProfiles[j].Name.ValueString()
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
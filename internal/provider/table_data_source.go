@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"time"
+)
+
+var _ datasource.DataSource = &TableDataSource{}
+var _ datasource.DataSourceWithConfigure = &TableDataSource{}
+
+func NewTableDataSource() datasource.DataSource {
+	return &TableDataSource{}
+}
+
+type TableDataSource struct {
+	client *util.Client
+}
+
+type TableDataSourceModel struct {
+	Id                       types.String          `tfsdk:"id"`
+	WarehouseId              types.String          `tfsdk:"warehouse_id"`
+	Database                 types.String          `tfsdk:"database"`
+	Name                     types.String          `tfsdk:"name"`
+	Location                 types.String          `tfsdk:"location"`
+	MetadataLocation         types.String          `tfsdk:"metadata_location"`
+	FormatVersion            types.Int64           `tfsdk:"format_version"`
+	SchemaId                 types.Int64           `tfsdk:"schema_id"`
+	Columns                  []TableColumnModel    `tfsdk:"columns"`
+	SchemaJson               types.String          `tfsdk:"schema_json"`
+	PartitionSpec            []PartitionFieldModel `tfsdk:"partition_spec"`
+	SortOrder                []SortFieldModel      `tfsdk:"sort_order"`
+	Properties               types.Map             `tfsdk:"properties"`
+	CurrentSnapshotId        types.Int64           `tfsdk:"current_snapshot_id"`
+	CurrentSnapshotTimestamp types.String          `tfsdk:"current_snapshot_timestamp"`
+}
+
+type TableColumnModel struct {
+	Id       types.Int64  `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Required types.Bool   `tfsdk:"required"`
+	Doc      types.String `tfsdk:"doc"`
+}
+
+type PartitionFieldModel struct {
+	Name         types.String `tfsdk:"name"`
+	SourceColumn types.String `tfsdk:"source_column"`
+	Transform    types.String `tfsdk:"transform"`
+	FieldId      types.Int64  `tfsdk:"field_id"`
+}
+
+type SortFieldModel struct {
+	SourceColumn types.String `tfsdk:"source_column"`
+	Transform    types.String `tfsdk:"transform"`
+	Direction    types.String `tfsdk:"direction"`
+	NullOrder    types.String `tfsdk:"null_order"`
+}
+
+func (d *TableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
+
+func (d *TableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a table's current metadata from the Iceberg REST catalog",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Table UUID",
+				Computed:            true,
+			},
+			"warehouse_id": schema.StringAttribute{
+				MarkdownDescription: "Warehouse ID",
+				Required:            true,
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database Name",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Table Name",
+				Required:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Storage Location of the table",
+				Computed:            true,
+			},
+			"metadata_location": schema.StringAttribute{
+				MarkdownDescription: "Location of the table's current metadata file",
+				Computed:            true,
+			},
+			"format_version": schema.Int64Attribute{
+				MarkdownDescription: "Iceberg format version",
+				Computed:            true,
+			},
+			"schema_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the current schema",
+				Computed:            true,
+			},
+			"columns": schema.ListNestedAttribute{
+				MarkdownDescription: "Top level columns of the current schema",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Field ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Column Name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Primitive type name such as `long`, or the JSON of a struct, list or map type",
+							Computed:            true,
+						},
+						"required": schema.BoolAttribute{
+							MarkdownDescription: "Whether the column can't be null",
+							Computed:            true,
+						},
+						"doc": schema.StringAttribute{
+							MarkdownDescription: "Column documentation",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"schema_json": schema.StringAttribute{
+				MarkdownDescription: "Current schema as Iceberg schema JSON",
+				Computed:            true,
+			},
+			"partition_spec": schema.ListNestedAttribute{
+				MarkdownDescription: "Fields of the default partition spec, empty for unpartitioned tables",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Partition field name",
+							Computed:            true,
+						},
+						"source_column": schema.StringAttribute{
+							MarkdownDescription: "Column the partition is derived from, dotted for nested fields",
+							Computed:            true,
+						},
+						"transform": schema.StringAttribute{
+							MarkdownDescription: "Partition transform, e.g. `day` or `bucket[16]`",
+							Computed:            true,
+						},
+						"field_id": schema.Int64Attribute{
+							MarkdownDescription: "Partition field ID",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"sort_order": schema.ListNestedAttribute{
+				MarkdownDescription: "Fields of the default sort order, empty for unsorted tables",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_column": schema.StringAttribute{
+							MarkdownDescription: "Column sorted by, dotted for nested fields",
+							Computed:            true,
+						},
+						"transform": schema.StringAttribute{
+							MarkdownDescription: "Transform applied before sorting",
+							Computed:            true,
+						},
+						"direction": schema.StringAttribute{
+							MarkdownDescription: "`asc` or `desc`",
+							Computed:            true,
+						},
+						"null_order": schema.StringAttribute{
+							MarkdownDescription: "`nulls-first` or `nulls-last`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"properties": schema.MapAttribute{
+				MarkdownDescription: "Table properties",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"current_snapshot_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the current snapshot, null when the table has no data",
+				Computed:            true,
+			},
+			"current_snapshot_timestamp": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 time the current snapshot was committed, null when the table has no data",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TableDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TableDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := data.Database.ValueString()
+	name := data.Name.ValueString()
+	table, err := d.client.V1.LoadTable(data.WarehouseId.ValueString(), database, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error loading table", fmt.Sprintf("Could not load table %s.%s: %s", database, name, err.Error()))
+		return
+	}
+	if table == nil {
+		resp.Diagnostics.AddError("Table not found", fmt.Sprintf("Could not find table %s.%s", database, name))
+		return
+	}
+
+	resp.Diagnostics.Append(setTableMetadata(ctx, &data, table)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func setTableMetadata(ctx context.Context, data *TableDataSourceModel, table *tabular.LoadTableResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+	metadata := table.Metadata
+
+	data.Id = types.StringValue(metadata.TableUuid)
+	data.Location = types.StringValue(metadata.Location)
+	data.MetadataLocation = types.StringValue(table.MetadataLocation)
+	data.FormatVersion = types.Int64Value(int64(metadata.FormatVersion))
+
+	currentSchema := metadata.CurrentSchema()
+	if currentSchema == nil {
+		diags.AddError("Table in unexpected state", fmt.Sprintf("Table metadata has no schema with the current schema id %d", metadata.CurrentSchemaId))
+		return diags
+	}
+	fieldNames := icebergFieldNames(currentSchema)
+	data.SchemaId = types.Int64Value(int64(currentSchema.SchemaId))
+	data.Columns = make([]TableColumnModel, 0, len(currentSchema.Fields))
+	for _, field := range currentSchema.Fields {
+		column := TableColumnModel{
+			Id:       types.Int64Value(int64(field.Id)),
+			Name:     types.StringValue(field.Name),
+			Type:     types.StringValue(icebergTypeString(field.Type)),
+			Required: types.BoolValue(field.Required),
+			Doc:      types.StringNull(),
+		}
+		if field.Doc != "" {
+			column.Doc = types.StringValue(field.Doc)
+		}
+		data.Columns = append(data.Columns, column)
+	}
+	schemaJson, err := json.Marshal(currentSchema)
+	if err != nil {
+		diags.AddError("Error rendering schema", err.Error())
+		return diags
+	}
+	data.SchemaJson = types.StringValue(string(schemaJson))
+
+	data.PartitionSpec = make([]PartitionFieldModel, 0)
+	if spec := metadata.DefaultPartitionSpec(); spec != nil {
+		for _, field := range spec.Fields {
+			data.PartitionSpec = append(data.PartitionSpec, PartitionFieldModel{
+				Name:         types.StringValue(field.Name),
+				SourceColumn: types.StringValue(fieldNames[field.SourceId]),
+				Transform:    types.StringValue(field.Transform),
+				FieldId:      types.Int64Value(int64(field.FieldId)),
+			})
+		}
+	}
+
+	data.SortOrder = make([]SortFieldModel, 0)
+	if sortOrder := metadata.DefaultSortOrder(); sortOrder != nil {
+		for _, field := range sortOrder.Fields {
+			data.SortOrder = append(data.SortOrder, SortFieldModel{
+				SourceColumn: types.StringValue(fieldNames[field.SourceId]),
+				Transform:    types.StringValue(field.Transform),
+				Direction:    types.StringValue(field.Direction),
+				NullOrder:    types.StringValue(field.NullOrder),
+			})
+		}
+	}
+
+	properties := metadata.Properties
+	if properties == nil {
+		properties = map[string]string{}
+	}
+	var propertyDiags diag.Diagnostics
+	data.Properties, propertyDiags = types.MapValueFrom(ctx, types.StringType, properties)
+	diags.Append(propertyDiags...)
+
+	data.CurrentSnapshotId = types.Int64Null()
+	data.CurrentSnapshotTimestamp = types.StringNull()
+	if snapshot := metadata.CurrentSnapshot(); snapshot != nil {
+		data.CurrentSnapshotId = types.Int64Value(snapshot.SnapshotId)
+		data.CurrentSnapshotTimestamp = types.StringValue(time.UnixMilli(snapshot.TimestampMs).UTC().Format(time.RFC3339))
+	}
+	return diags
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func TestSetTableMetadata(t *testing.T) {
+	var table tabular.LoadTableResult
+	assert.NoError(t, json.Unmarshal([]byte(`{
+  "metadata-location": "s3://bucket/db/events/metadata/00001.metadata.json",
+  "metadata": {
+    "format-version": 2,
+    "table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+    "location": "s3://bucket/db/events",
+    "current-schema-id": 0,
+    "schemas": [`+testIcebergSchema+`],
+    "default-spec-id": 1,
+    "partition-specs": [
+      {"spec-id": 0, "fields": []},
+      {"spec-id": 1, "fields": [{"source-id": 5, "field-id": 1000, "name": "lat_bucket", "transform": "bucket[16]"}]}
+    ],
+    "default-sort-order-id": 1,
+    "sort-orders": [{"order-id": 1, "fields": [{"source-id": 1, "transform": "identity", "direction": "asc", "null-order": "nulls-first"}]}],
+    "properties": {"write.format.default": "parquet"},
+    "current-snapshot-id": 42,
+    "snapshots": [{"snapshot-id": 42, "timestamp-ms": 1700000000000, "manifest-list": "s3://bucket/snap-42.avro"}]
+  }
+}`), &table))
+
+	var data TableDataSourceModel
+	diags := setTableMetadata(context.Background(), &data, &table)
+	assert.False(t, diags.HasError())
+
+	assert.Equal(t, "9c12d441-03fe-4693-9a96-a0705ddf69c1", data.Id.ValueString())
+	assert.Equal(t, int64(2), data.FormatVersion.ValueInt64())
+	assert.Len(t, data.Columns, 4)
+	assert.Equal(t, "long", data.Columns[0].Type.ValueString())
+	assert.True(t, data.Columns[0].Required.ValueBool())
+	assert.True(t, data.Columns[1].Doc.IsNull())
+	assert.Equal(t, []PartitionFieldModel{{
+		Name:         types.StringValue("lat_bucket"),
+		SourceColumn: types.StringValue("location.lat"),
+		Transform:    types.StringValue("bucket[16]"),
+		FieldId:      types.Int64Value(1000),
+	}}, data.PartitionSpec)
+	assert.Equal(t, "id", data.SortOrder[0].SourceColumn.ValueString())
+	assert.Equal(t, int64(42), data.CurrentSnapshotId.ValueInt64())
+	assert.Equal(t, "2023-11-14T22:13:20Z", data.CurrentSnapshotTimestamp.ValueString())
+	assert.True(t, json.Valid([]byte(data.SchemaJson.ValueString())))
+}
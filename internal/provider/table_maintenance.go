@@ -0,0 +1,502 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/validators"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = &tableMaintenanceResource{}
+	_ resource.ResourceWithConfigure   = &tableMaintenanceResource{}
+	_ resource.ResourceWithImportState = &tableMaintenanceResource{}
+)
+
+// Table properties holding maintenance settings. The history and write properties are read by Iceberg itself, the
+// optimizer ones by Tabular's maintenance service, which falls back to the database's properties for tables that
+// don't set them.
+const (
+	maxSnapshotAgeMsProperty            = "history.expire.max-snapshot-age-ms"
+	minSnapshotsToKeepProperty          = "history.expire.min-snapshots-to-keep"
+	targetFileSizeBytesProperty         = "write.target-file-size-bytes"
+	metadataPreviousVersionsMaxProperty = "write.metadata.previous-versions-max"
+	metadataDeleteAfterCommitProperty   = "write.metadata.delete-after-commit.enabled"
+	compactionEnabledProperty           = "optimizer.compaction.enabled"
+	orphanFileCleanupEnabledProperty    = "optimizer.orphan-file-cleanup.enabled"
+)
+
+var maintenanceProperties = []string{
+	maxSnapshotAgeMsProperty,
+	minSnapshotsToKeepProperty,
+	targetFileSizeBytesProperty,
+	metadataPreviousVersionsMaxProperty,
+	metadataDeleteAfterCommitProperty,
+	compactionEnabledProperty,
+	orphanFileCleanupEnabledProperty,
+}
+
+var maintenanceSettingsAttrTypes = map[string]attr.Type{
+	"max_snapshot_age_ms":                  types.Int64Type,
+	"min_snapshots_to_keep":                types.Int64Type,
+	"target_file_size_bytes":               types.Int64Type,
+	"metadata_previous_versions_max":       types.Int64Type,
+	"metadata_delete_after_commit_enabled": types.BoolType,
+	"compaction_enabled":                   types.BoolType,
+	"orphan_file_cleanup_enabled":          types.BoolType,
+}
+
+type tableMaintenanceResource struct {
+	client *util.Client
+}
+
+func NewTableMaintenanceResource() resource.Resource {
+	return &tableMaintenanceResource{}
+}
+
+type tableMaintenanceResourceModel struct {
+	Id                               types.String `tfsdk:"id"`
+	WarehouseId                      types.String `tfsdk:"warehouse_id"`
+	Database                         types.String `tfsdk:"database"`
+	Table                            types.String `tfsdk:"table"`
+	MaxSnapshotAgeMs                 types.Int64  `tfsdk:"max_snapshot_age_ms"`
+	MinSnapshotsToKeep               types.Int64  `tfsdk:"min_snapshots_to_keep"`
+	TargetFileSizeBytes              types.Int64  `tfsdk:"target_file_size_bytes"`
+	MetadataPreviousVersionsMax      types.Int64  `tfsdk:"metadata_previous_versions_max"`
+	MetadataDeleteAfterCommitEnabled types.Bool   `tfsdk:"metadata_delete_after_commit_enabled"`
+	CompactionEnabled                types.Bool   `tfsdk:"compaction_enabled"`
+	OrphanFileCleanupEnabled         types.Bool   `tfsdk:"orphan_file_cleanup_enabled"`
+	EffectiveSettings                types.Object `tfsdk:"effective_settings"`
+}
+
+type maintenanceSettingsModel struct {
+	MaxSnapshotAgeMs                 types.Int64 `tfsdk:"max_snapshot_age_ms"`
+	MinSnapshotsToKeep               types.Int64 `tfsdk:"min_snapshots_to_keep"`
+	TargetFileSizeBytes              types.Int64 `tfsdk:"target_file_size_bytes"`
+	MetadataPreviousVersionsMax      types.Int64 `tfsdk:"metadata_previous_versions_max"`
+	MetadataDeleteAfterCommitEnabled types.Bool  `tfsdk:"metadata_delete_after_commit_enabled"`
+	CompactionEnabled                types.Bool  `tfsdk:"compaction_enabled"`
+	OrphanFileCleanupEnabled         types.Bool  `tfsdk:"orphan_file_cleanup_enabled"`
+}
+
+func (r *tableMaintenanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*util.Client)
+}
+
+func (r *tableMaintenanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_maintenance"
+}
+
+func (r *tableMaintenanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Maintenance settings of a table, or of every table in a database, stored as properties. " +
+			"Maintenance properties set outside of Terraform are removed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "warehouseId/database, or warehouseId/database/table",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"warehouse_id": schema.StringAttribute{
+				Description: "Warehouse ID (uuid)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Database Name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table": schema.StringAttribute{
+				Description: "Table Name. When unset the settings apply to the database, and so to its tables that don't override them.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_snapshot_age_ms": schema.Int64Attribute{
+				Description: "Age after which snapshots are expired, at least 1 hour (" + maxSnapshotAgeMsProperty + ")",
+				Optional:    true,
+				Validators: []validator.Int64{
+					validators.Int64RangeValidator{Min: 60 * 60 * 1000, Max: math.MaxInt64},
+				},
+			},
+			"min_snapshots_to_keep": schema.Int64Attribute{
+				Description: "Snapshots kept regardless of their age, at least 1 (" + minSnapshotsToKeepProperty + ")",
+				Optional:    true,
+				Validators: []validator.Int64{
+					validators.Int64RangeValidator{Min: 1, Max: math.MaxInt32},
+				},
+			},
+			"target_file_size_bytes": schema.Int64Attribute{
+				Description: "Size data files are written and compacted to, between 1 MiB and 2 GiB (" + targetFileSizeBytesProperty + ")",
+				Optional:    true,
+				Validators: []validator.Int64{
+					validators.Int64RangeValidator{Min: 1 << 20, Max: 2 << 30},
+				},
+			},
+			"metadata_previous_versions_max": schema.Int64Attribute{
+				Description: "Previous metadata files kept in the metadata log, at least 1 (" + metadataPreviousVersionsMaxProperty + ")",
+				Optional:    true,
+				Validators: []validator.Int64{
+					validators.Int64RangeValidator{Min: 1, Max: math.MaxInt32},
+				},
+			},
+			"metadata_delete_after_commit_enabled": schema.BoolAttribute{
+				Description: "Whether metadata files dropped from the metadata log are deleted (" + metadataDeleteAfterCommitProperty + ")",
+				Optional:    true,
+			},
+			"compaction_enabled": schema.BoolAttribute{
+				Description: "Whether Tabular compacts data files (" + compactionEnabledProperty + ")",
+				Optional:    true,
+			},
+			"orphan_file_cleanup_enabled": schema.BoolAttribute{
+				Description: "Whether Tabular deletes files no snapshot references (" + orphanFileCleanupEnabledProperty + ")",
+				Optional:    true,
+			},
+			"effective_settings": schema.SingleNestedAttribute{
+				Description: "Settings in effect, including those a table inherits from its database. Unset ones fall back to Tabular's defaults.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"max_snapshot_age_ms":                  schema.Int64Attribute{Computed: true},
+					"min_snapshots_to_keep":                schema.Int64Attribute{Computed: true},
+					"target_file_size_bytes":               schema.Int64Attribute{Computed: true},
+					"metadata_previous_versions_max":       schema.Int64Attribute{Computed: true},
+					"metadata_delete_after_commit_enabled": schema.BoolAttribute{Computed: true},
+					"compaction_enabled":                   schema.BoolAttribute{Computed: true},
+					"orphan_file_cleanup_enabled":          schema.BoolAttribute{Computed: true},
+				},
+			},
+		},
+	}
+}
+
+func (r *tableMaintenanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" || (len(parts) == 3 && parts[2] == "") {
+		resp.Diagnostics.AddError("Could not parse ", "Expected warehouseId/database or warehouseId/database/table")
+		return
+	}
+
+	state := tableMaintenanceResourceModel{
+		Id:                types.StringValue(req.ID),
+		WarehouseId:       types.StringValue(parts[0]),
+		Database:          types.StringValue(parts[1]),
+		Table:             types.StringNull(),
+		EffectiveSettings: types.ObjectNull(maintenanceSettingsAttrTypes),
+	}
+	if len(parts) == 3 {
+		state.Table = types.StringValue(parts[2])
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *tableMaintenanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tableMaintenanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, err := r.getTarget(&state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading maintenance settings", err.Error())
+		return
+	}
+	if target == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(setMaintenanceState(ctx, &state, target)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *tableMaintenanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tableMaintenanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(plan.WarehouseId.ValueString() + "/" + plan.Database.ValueString())
+	if !plan.Table.IsNull() {
+		plan.Id = types.StringValue(plan.Id.ValueString() + "/" + plan.Table.ValueString())
+	}
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *tableMaintenanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan tableMaintenanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *tableMaintenanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tableMaintenanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, err := r.getTarget(&state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing maintenance settings", err.Error())
+		return
+	}
+	if target != nil {
+		if err := r.setProperties(&state, target, map[string]string{}); err != nil {
+			resp.Diagnostics.AddError("Error removing maintenance settings", err.Error())
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// maintenanceTarget holds the properties of the table or database the settings are stored on
+type maintenanceTarget struct {
+	properties map[string]string
+	// databaseProperties are the properties a table inherits from, nil when the target is the database itself
+	databaseProperties map[string]string
+	tableUuid          string
+}
+
+// getTarget loads the target's properties, or returns nil when the table or database doesn't exist
+func (r *tableMaintenanceResource) getTarget(data *tableMaintenanceResourceModel) (*maintenanceTarget, error) {
+	warehouseId := data.WarehouseId.ValueString()
+	database := data.Database.ValueString()
+	databaseProperties, err := r.client.V1.GetDatabaseProperties(warehouseId, database)
+	if err != nil {
+		return nil, fmt.Errorf("could not load database %s: %w", database, err)
+	}
+	if databaseProperties == nil {
+		return nil, nil
+	}
+	if data.Table.IsNull() {
+		return &maintenanceTarget{properties: databaseProperties}, nil
+	}
+
+	table, err := r.client.V1.LoadTable(warehouseId, database, data.Table.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("could not load table %s.%s: %w", database, data.Table.ValueString(), err)
+	}
+	if table == nil {
+		return nil, nil
+	}
+	properties := table.Metadata.Properties
+	if properties == nil {
+		properties = make(map[string]string)
+	}
+	return &maintenanceTarget{properties: properties, databaseProperties: databaseProperties, tableUuid: table.Metadata.TableUuid}, nil
+}
+
+// setProperties replaces the target's maintenance properties with wanted, leaving its other properties alone
+func (r *tableMaintenanceResource) setProperties(data *tableMaintenanceResourceModel, target *maintenanceTarget, wanted map[string]string) error {
+	current := filterMaintenanceProperties(target.properties)
+	warehouseId := data.WarehouseId.ValueString()
+	database := data.Database.ValueString()
+	if data.Table.IsNull() {
+		changed, removed := diffProperties(current, wanted)
+		if len(changed) == 0 && len(removed) == 0 {
+			return nil
+		}
+		return r.client.V1.UpdateDatabaseProperties(warehouseId, database, removed, changed)
+	}
+
+	updates := propertyUpdates(current, wanted)
+	if len(updates) == 0 {
+		return nil
+	}
+	_, err := r.client.V1.CommitTable(warehouseId, database, data.Table.ValueString(), tabular.CommitTableRequest{
+		Requirements: []tabular.CatalogRequirement{tabular.AssertTableUuid(target.tableUuid)},
+		Updates:      updates,
+	})
+	return err
+}
+
+// apply writes the settings in data to the target and fills in the effective settings
+func (r *tableMaintenanceResource) apply(ctx context.Context, data *tableMaintenanceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	target, err := r.getTarget(data)
+	if err != nil {
+		diags.AddError("Error updating maintenance settings", err.Error())
+		return diags
+	}
+	if target == nil {
+		diags.AddError("Error updating maintenance settings", fmt.Sprintf("Could not find %s", strings.TrimPrefix(data.Id.ValueString(), data.WarehouseId.ValueString()+"/")))
+		return diags
+	}
+
+	wanted := data.settings().toProperties()
+	if err := r.setProperties(data, target, wanted); err != nil {
+		diags.AddError("Error updating maintenance settings", err.Error())
+		return diags
+	}
+
+	for _, property := range maintenanceProperties {
+		delete(target.properties, property)
+	}
+	for property, value := range wanted {
+		target.properties[property] = value
+	}
+	return setMaintenanceState(ctx, data, target)
+}
+
+// setMaintenanceState refreshes the settings in data from the target's properties. Values that can't be parsed, e.g.
+// written by another tool, are read as unset with a warning rather than failing every refresh.
+func setMaintenanceState(ctx context.Context, data *tableMaintenanceResourceModel, target *maintenanceTarget) diag.Diagnostics {
+	var diags diag.Diagnostics
+	settings, err := maintenanceSettingsFromProperties(target.properties)
+	if err != nil {
+		diags.AddWarning("Invalid maintenance property", "Reading the setting as unset: "+err.Error())
+	}
+	data.MaxSnapshotAgeMs = settings.MaxSnapshotAgeMs
+	data.MinSnapshotsToKeep = settings.MinSnapshotsToKeep
+	data.TargetFileSizeBytes = settings.TargetFileSizeBytes
+	data.MetadataPreviousVersionsMax = settings.MetadataPreviousVersionsMax
+	data.MetadataDeleteAfterCommitEnabled = settings.MetadataDeleteAfterCommitEnabled
+	data.CompactionEnabled = settings.CompactionEnabled
+	data.OrphanFileCleanupEnabled = settings.OrphanFileCleanupEnabled
+
+	inherited := filterMaintenanceProperties(target.databaseProperties)
+	for property := range target.properties {
+		delete(inherited, property)
+	}
+	if _, err := maintenanceSettingsFromProperties(inherited); err != nil {
+		diags.AddWarning("Invalid database maintenance property", "Reading the effective setting as unset: "+err.Error())
+	}
+	effective := filterMaintenanceProperties(target.properties)
+	for property, value := range inherited {
+		effective[property] = value
+	}
+	// Invalid values were warned about above
+	effectiveSettings, _ := maintenanceSettingsFromProperties(effective)
+	var d diag.Diagnostics
+	data.EffectiveSettings, d = types.ObjectValueFrom(ctx, maintenanceSettingsAttrTypes, effectiveSettings)
+	diags.Append(d...)
+	return diags
+}
+
+func (m *tableMaintenanceResourceModel) settings() maintenanceSettingsModel {
+	return maintenanceSettingsModel{
+		MaxSnapshotAgeMs:                 m.MaxSnapshotAgeMs,
+		MinSnapshotsToKeep:               m.MinSnapshotsToKeep,
+		TargetFileSizeBytes:              m.TargetFileSizeBytes,
+		MetadataPreviousVersionsMax:      m.MetadataPreviousVersionsMax,
+		MetadataDeleteAfterCommitEnabled: m.MetadataDeleteAfterCommitEnabled,
+		CompactionEnabled:                m.CompactionEnabled,
+		OrphanFileCleanupEnabled:         m.OrphanFileCleanupEnabled,
+	}
+}
+
+func (s maintenanceSettingsModel) toProperties() map[string]string {
+	properties := make(map[string]string)
+	setInt := func(property string, value types.Int64) {
+		if !value.IsNull() && !value.IsUnknown() {
+			properties[property] = strconv.FormatInt(value.ValueInt64(), 10)
+		}
+	}
+	setBool := func(property string, value types.Bool) {
+		if !value.IsNull() && !value.IsUnknown() {
+			properties[property] = strconv.FormatBool(value.ValueBool())
+		}
+	}
+	setInt(maxSnapshotAgeMsProperty, s.MaxSnapshotAgeMs)
+	setInt(minSnapshotsToKeepProperty, s.MinSnapshotsToKeep)
+	setInt(targetFileSizeBytesProperty, s.TargetFileSizeBytes)
+	setInt(metadataPreviousVersionsMaxProperty, s.MetadataPreviousVersionsMax)
+	setBool(metadataDeleteAfterCommitProperty, s.MetadataDeleteAfterCommitEnabled)
+	setBool(compactionEnabledProperty, s.CompactionEnabled)
+	setBool(orphanFileCleanupEnabledProperty, s.OrphanFileCleanupEnabled)
+	return properties
+}
+
+// maintenanceSettingsFromProperties parses the maintenance properties. Values that can't be parsed are left null and
+// reported together in the error.
+func maintenanceSettingsFromProperties(properties map[string]string) (maintenanceSettingsModel, error) {
+	var errs []error
+	getInt := func(property string) types.Int64 {
+		value, ok := properties[property]
+		if !ok {
+			return types.Int64Null()
+		}
+		parsed, parseErr := strconv.ParseInt(value, 10, 64)
+		if parseErr != nil {
+			errs = append(errs, fmt.Errorf("%s is %q, expected an integer", property, value))
+			return types.Int64Null()
+		}
+		return types.Int64Value(parsed)
+	}
+	getBool := func(property string) types.Bool {
+		value, ok := properties[property]
+		if !ok {
+			return types.BoolNull()
+		}
+		parsed, parseErr := strconv.ParseBool(value)
+		if parseErr != nil {
+			errs = append(errs, fmt.Errorf("%s is %q, expected true or false", property, value))
+			return types.BoolNull()
+		}
+		return types.BoolValue(parsed)
+	}
+	settings := maintenanceSettingsModel{
+		MaxSnapshotAgeMs:                 getInt(maxSnapshotAgeMsProperty),
+		MinSnapshotsToKeep:               getInt(minSnapshotsToKeepProperty),
+		TargetFileSizeBytes:              getInt(targetFileSizeBytesProperty),
+		MetadataPreviousVersionsMax:      getInt(metadataPreviousVersionsMaxProperty),
+		MetadataDeleteAfterCommitEnabled: getBool(metadataDeleteAfterCommitProperty),
+		CompactionEnabled:                getBool(compactionEnabledProperty),
+		OrphanFileCleanupEnabled:         getBool(orphanFileCleanupEnabledProperty),
+	}
+	return settings, errors.Join(errs...)
+}
+
+func filterMaintenanceProperties(properties map[string]string) map[string]string {
+	filtered := make(map[string]string)
+	for _, property := range maintenanceProperties {
+		if value, ok := properties[property]; ok {
+			filtered[property] = value
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceSettingsProperties(t *testing.T) {
+	settings := maintenanceSettingsModel{
+		MaxSnapshotAgeMs:                 types.Int64Value(86400000),
+		MinSnapshotsToKeep:               types.Int64Null(),
+		TargetFileSizeBytes:              types.Int64Value(268435456),
+		MetadataPreviousVersionsMax:      types.Int64Null(),
+		MetadataDeleteAfterCommitEnabled: types.BoolNull(),
+		CompactionEnabled:                types.BoolValue(false),
+		OrphanFileCleanupEnabled:         types.BoolNull(),
+	}
+	properties := settings.toProperties()
+	assert.Equal(t, map[string]string{
+		"history.expire.max-snapshot-age-ms": "86400000",
+		"write.target-file-size-bytes":       "268435456",
+		"optimizer.compaction.enabled":       "false",
+	}, properties)
+
+	parsed, err := maintenanceSettingsFromProperties(properties)
+	assert.NoError(t, err)
+	assert.Equal(t, settings, parsed)
+
+	_, err = maintenanceSettingsFromProperties(map[string]string{"history.expire.min-snapshots-to-keep": "many"})
+	assert.ErrorContains(t, err, "expected an integer")
+}
+
+func TestSetMaintenanceState(t *testing.T) {
+	target := &maintenanceTarget{
+		properties: map[string]string{
+			"history.expire.min-snapshots-to-keep": "5",
+			"write.format.default":                 "parquet",
+		},
+		databaseProperties: map[string]string{
+			"history.expire.min-snapshots-to-keep": "1",
+			"optimizer.compaction.enabled":         "true",
+			"location":                             "s3://bucket/db",
+		},
+	}
+
+	var data tableMaintenanceResourceModel
+	diags := setMaintenanceState(context.Background(), &data, target)
+	assert.False(t, diags.HasError())
+
+	assert.Equal(t, int64(5), data.MinSnapshotsToKeep.ValueInt64())
+	assert.True(t, data.CompactionEnabled.IsNull())
+
+	var effective maintenanceSettingsModel
+	assert.False(t, data.EffectiveSettings.As(context.Background(), &effective, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, int64(5), effective.MinSnapshotsToKeep.ValueInt64())
+	assert.True(t, effective.CompactionEnabled.ValueBool())
+	assert.True(t, effective.MaxSnapshotAgeMs.IsNull())
+}
+
+func TestSetMaintenanceStateInvalidProperties(t *testing.T) {
+	target := &maintenanceTarget{
+		properties: map[string]string{
+			"history.expire.min-snapshots-to-keep": "many",
+			"write.target-file-size-bytes":         "1024",
+		},
+		databaseProperties: map[string]string{
+			"optimizer.compaction.enabled": "sometimes",
+		},
+	}
+
+	var data tableMaintenanceResourceModel
+	diags := setMaintenanceState(context.Background(), &data, target)
+	// Refreshing still works, so the next apply can fix the properties
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 2, diags.WarningsCount())
+
+	assert.True(t, data.MinSnapshotsToKeep.IsNull())
+	assert.Equal(t, int64(1024), data.TargetFileSizeBytes.ValueInt64())
+
+	var effective maintenanceSettingsModel
+	assert.False(t, data.EffectiveSettings.As(context.Background(), &effective, basetypes.ObjectAsOptions{}).HasError())
+	assert.True(t, effective.CompactionEnabled.IsNull())
+	assert.Equal(t, int64(1024), effective.TargetFileSizeBytes.ValueInt64())
+}
@@ -0,0 +1,378 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/validators"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"math"
+	"strings"
+)
+
+var (
+	_ resource.Resource                   = &tableRefResource{}
+	_ resource.ResourceWithConfigure      = &tableRefResource{}
+	_ resource.ResourceWithImportState    = &tableRefResource{}
+	_ resource.ResourceWithValidateConfig = &tableRefResource{}
+)
+
+const (
+	refTypeBranch = "branch"
+	refTypeTag    = "tag"
+	// mainBranch is the table's current state, which writers move and dropping it would empty the table
+	mainBranch = "main"
+)
+
+type tableRefResource struct {
+	client *util.Client
+}
+
+func NewTableRefResource() resource.Resource {
+	return &tableRefResource{}
+}
+
+type tableRefResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	WarehouseId        types.String `tfsdk:"warehouse_id"`
+	Database           types.String `tfsdk:"database"`
+	Table              types.String `tfsdk:"table"`
+	Name               types.String `tfsdk:"name"`
+	Type               types.String `tfsdk:"type"`
+	SnapshotId         types.Int64  `tfsdk:"snapshot_id"`
+	MaxRefAgeMs        types.Int64  `tfsdk:"max_ref_age_ms"`
+	MaxSnapshotAgeMs   types.Int64  `tfsdk:"max_snapshot_age_ms"`
+	MinSnapshotsToKeep types.Int64  `tfsdk:"min_snapshots_to_keep"`
+}
+
+func (r *tableRefResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*util.Client)
+}
+
+func (r *tableRefResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_ref"
+}
+
+func (r *tableRefResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A branch or tag on an Iceberg table",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "warehouseId/database/table/name",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"warehouse_id": schema.StringAttribute{
+				Description: "Warehouse ID (uuid)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Database Name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table": schema.StringAttribute{
+				Description: "Table Name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Branch or tag name. The main branch can't be managed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "branch or tag",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.StringOneOfValidator{Values: []string{refTypeBranch, refTypeTag}},
+				},
+			},
+			"snapshot_id": schema.Int64Attribute{
+				Description: "Snapshot the ref points at. Defaults to the table's current snapshot. " +
+					"Leave unset for branches that are written to, or Terraform will move them back.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_ref_age_ms": schema.Int64Attribute{
+				Description: "Age after which the ref is removed. Defaults to keeping it forever.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					validators.Int64RangeValidator{Min: 1, Max: math.MaxInt64},
+				},
+			},
+			"max_snapshot_age_ms": schema.Int64Attribute{
+				Description: "Branch only. Age after which snapshots on the branch are expired. Defaults to the table's history.expire.max-snapshot-age-ms.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					validators.Int64RangeValidator{Min: 1, Max: math.MaxInt64},
+				},
+			},
+			"min_snapshots_to_keep": schema.Int64Attribute{
+				Description: "Branch only. Snapshots on the branch kept regardless of their age. Defaults to the table's history.expire.min-snapshots-to-keep.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					validators.Int64RangeValidator{Min: 1, Max: math.MaxInt32},
+				},
+			},
+		},
+	}
+}
+
+func (r *tableRefResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data tableRefResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Name.ValueString() == mainBranch {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid ref name", "The main branch can't be managed by tabular_table_ref")
+	}
+	if data.Type.ValueString() == refTypeTag {
+		if !data.MaxSnapshotAgeMs.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("max_snapshot_age_ms"), "Invalid tag", "max_snapshot_age_ms can only be set on branches")
+		}
+		if !data.MinSnapshotsToKeep.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("min_snapshots_to_keep"), "Invalid tag", "min_snapshots_to_keep can only be set on branches")
+		}
+	}
+}
+
+func (r *tableRefResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 4)
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError("Could not parse ", "Expected warehouseId/database/table/name")
+		return
+	}
+
+	state := tableRefResourceModel{
+		Id:          types.StringValue(req.ID),
+		WarehouseId: types.StringValue(parts[0]),
+		Database:    types.StringValue(parts[1]),
+		Table:       types.StringValue(parts[2]),
+		Name:        types.StringValue(parts[3]),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *tableRefResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tableRefResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.loadTable(&state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error loading table", err.Error())
+		return
+	}
+	if table == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	ref, ok := table.Metadata.Refs[state.Name.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setTableRefState(&state, ref)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *tableRefResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tableRefResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.loadTable(&plan)
+	if err == nil && table == nil {
+		err = fmt.Errorf("table %s.%s doesn't exist", plan.Database.ValueString(), plan.Table.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error loading table", err.Error())
+		return
+	}
+
+	ref, err := newSnapshotRef(&plan, &table.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ref", err.Error())
+		return
+	}
+	name := plan.Name.ValueString()
+	table, err = r.client.V1.CommitTable(plan.WarehouseId.ValueString(), plan.Database.ValueString(), plan.Table.ValueString(), tabular.CommitTableRequest{
+		Requirements: []tabular.CatalogRequirement{
+			tabular.AssertTableUuid(table.Metadata.TableUuid),
+			tabular.AssertRefSnapshotId(name, nil),
+		},
+		Updates: []tabular.CatalogUpdate{tabular.SetSnapshotRefUpdate(name, ref)},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating ref", fmt.Sprintf("Could not create %s %s: %s", ref.Type, name, err.Error()))
+		return
+	}
+
+	plan.Id = types.StringValue(strings.Join([]string{
+		plan.WarehouseId.ValueString(), plan.Database.ValueString(), plan.Table.ValueString(), name,
+	}, "/"))
+	setTableRefState(&plan, table.Metadata.Refs[name])
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *tableRefResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state tableRefResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.loadTable(&plan)
+	if err == nil && table == nil {
+		err = fmt.Errorf("table %s.%s doesn't exist", plan.Database.ValueString(), plan.Table.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error loading table", err.Error())
+		return
+	}
+
+	ref, err := newSnapshotRef(&plan, &table.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ref", err.Error())
+		return
+	}
+	// Fails rather than overwriting when something else moved the ref since it was last read
+	stateSnapshotId := state.SnapshotId.ValueInt64()
+	name := plan.Name.ValueString()
+	table, err = r.client.V1.CommitTable(plan.WarehouseId.ValueString(), plan.Database.ValueString(), plan.Table.ValueString(), tabular.CommitTableRequest{
+		Requirements: []tabular.CatalogRequirement{
+			tabular.AssertTableUuid(table.Metadata.TableUuid),
+			tabular.AssertRefSnapshotId(name, &stateSnapshotId),
+		},
+		Updates: []tabular.CatalogUpdate{tabular.SetSnapshotRefUpdate(name, ref)},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating ref", fmt.Sprintf("Could not update %s %s: %s", ref.Type, name, err.Error()))
+		return
+	}
+
+	setTableRefState(&plan, table.Metadata.Refs[name])
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *tableRefResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tableRefResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.loadTable(&state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error loading table", err.Error())
+		return
+	}
+	name := state.Name.ValueString()
+	if table != nil {
+		if _, ok := table.Metadata.Refs[name]; ok {
+			_, err = r.client.V1.CommitTable(state.WarehouseId.ValueString(), state.Database.ValueString(), state.Table.ValueString(), tabular.CommitTableRequest{
+				Requirements: []tabular.CatalogRequirement{tabular.AssertTableUuid(table.Metadata.TableUuid)},
+				Updates:      []tabular.CatalogUpdate{tabular.RemoveSnapshotRefUpdate(name)},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Error removing ref", fmt.Sprintf("Could not remove %s: %s", name, err.Error()))
+				return
+			}
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *tableRefResource) loadTable(data *tableRefResourceModel) (*tabular.LoadTableResult, error) {
+	return r.client.V1.LoadTable(data.WarehouseId.ValueString(), data.Database.ValueString(), data.Table.ValueString())
+}
+
+// newSnapshotRef builds the ref in the plan, pointing it at the table's current snapshot when no snapshot is given
+func newSnapshotRef(plan *tableRefResourceModel, metadata *tabular.TableMetadata) (tabular.SnapshotRef, error) {
+	ref := tabular.SnapshotRef{Type: plan.Type.ValueString()}
+	if !plan.MaxRefAgeMs.IsNull() {
+		maxRefAgeMs := plan.MaxRefAgeMs.ValueInt64()
+		ref.MaxRefAgeMs = &maxRefAgeMs
+	}
+	if !plan.MaxSnapshotAgeMs.IsNull() {
+		maxSnapshotAgeMs := plan.MaxSnapshotAgeMs.ValueInt64()
+		ref.MaxSnapshotAgeMs = &maxSnapshotAgeMs
+	}
+	if !plan.MinSnapshotsToKeep.IsNull() {
+		minSnapshotsToKeep := int(plan.MinSnapshotsToKeep.ValueInt64())
+		ref.MinSnapshotsToKeep = &minSnapshotsToKeep
+	}
+
+	if plan.SnapshotId.IsUnknown() || plan.SnapshotId.IsNull() {
+		if metadata.CurrentSnapshotId == nil || *metadata.CurrentSnapshotId < 0 {
+			return ref, fmt.Errorf("the table has no snapshots yet, so snapshot_id must be set")
+		}
+		ref.SnapshotId = *metadata.CurrentSnapshotId
+		return ref, nil
+	}
+
+	ref.SnapshotId = plan.SnapshotId.ValueInt64()
+	for _, snapshot := range metadata.Snapshots {
+		if snapshot.SnapshotId == ref.SnapshotId {
+			return ref, nil
+		}
+	}
+	return ref, fmt.Errorf("the table has no snapshot %d", ref.SnapshotId)
+}
+
+func setTableRefState(data *tableRefResourceModel, ref tabular.SnapshotRef) {
+	data.Type = types.StringValue(ref.Type)
+	data.SnapshotId = types.Int64Value(ref.SnapshotId)
+	data.MaxRefAgeMs = types.Int64Null()
+	if ref.MaxRefAgeMs != nil {
+		data.MaxRefAgeMs = types.Int64Value(*ref.MaxRefAgeMs)
+	}
+	data.MaxSnapshotAgeMs = types.Int64Null()
+	if ref.MaxSnapshotAgeMs != nil {
+		data.MaxSnapshotAgeMs = types.Int64Value(*ref.MaxSnapshotAgeMs)
+	}
+	data.MinSnapshotsToKeep = types.Int64Null()
+	if ref.MinSnapshotsToKeep != nil {
+		data.MinSnapshotsToKeep = types.Int64Value(int64(*ref.MinSnapshotsToKeep))
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+func TestNewSnapshotRef(t *testing.T) {
+	currentSnapshotId := int64(42)
+	metadata := &tabular.TableMetadata{
+		CurrentSnapshotId: &currentSnapshotId,
+		Snapshots:         []tabular.Snapshot{{SnapshotId: 41}, {SnapshotId: 42}},
+	}
+	plan := &tableRefResourceModel{
+		Type:               types.StringValue(refTypeBranch),
+		SnapshotId:         types.Int64Unknown(),
+		MaxRefAgeMs:        types.Int64Null(),
+		MaxSnapshotAgeMs:   types.Int64Value(86400000),
+		MinSnapshotsToKeep: types.Int64Value(3),
+	}
+
+	ref, err := newSnapshotRef(plan, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), ref.SnapshotId)
+	assert.Nil(t, ref.MaxRefAgeMs)
+	assert.Equal(t, tabular.CatalogUpdate{
+		"action":                "set-snapshot-ref",
+		"ref-name":              "audit",
+		"type":                  "branch",
+		"snapshot-id":           int64(42),
+		"max-snapshot-age-ms":   int64(86400000),
+		"min-snapshots-to-keep": 3,
+	}, tabular.SetSnapshotRefUpdate("audit", ref))
+
+	var state tableRefResourceModel
+	setTableRefState(&state, ref)
+	assert.Equal(t, int64(42), state.SnapshotId.ValueInt64())
+	assert.True(t, state.MaxRefAgeMs.IsNull())
+	assert.Equal(t, int64(3), state.MinSnapshotsToKeep.ValueInt64())
+
+	plan.SnapshotId = types.Int64Value(41)
+	ref, err = newSnapshotRef(plan, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(41), ref.SnapshotId)
+
+	plan.SnapshotId = types.Int64Value(7)
+	_, err = newSnapshotRef(plan, metadata)
+	assert.ErrorContains(t, err, "no snapshot 7")
+
+	plan.SnapshotId = types.Int64Null()
+	_, err = newSnapshotRef(plan, &tabular.TableMetadata{})
+	assert.ErrorContains(t, err, "no snapshots yet")
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"sort"
+)
+
+var _ datasource.DataSource = &TablesDataSource{}
+var _ datasource.DataSourceWithConfigure = &TablesDataSource{}
+
+const (
+	tableTypeTable = "TABLE"
+	tableTypeView  = "VIEW"
+)
+
+func NewTablesDataSource() datasource.DataSource {
+	return &TablesDataSource{}
+}
+
+type TablesDataSource struct {
+	client *util.Client
+}
+
+type TablesDataSourceModel struct {
+	WarehouseId       types.String        `tfsdk:"warehouse_id"`
+	Database          types.String        `tfsdk:"database"`
+	NamePrefix        types.String        `tfsdk:"name_prefix"`
+	NameRegex         types.String        `tfsdk:"name_regex"`
+	IncludeViews      types.Bool          `tfsdk:"include_views"`
+	IncludeProperties types.Bool          `tfsdk:"include_properties"`
+	Names             types.List          `tfsdk:"names"`
+	Tables            []TableSummaryModel `tfsdk:"tables"`
+}
+
+type TableSummaryModel struct {
+	Name       types.String `tfsdk:"name"`
+	Identifier types.String `tfsdk:"identifier"`
+	Type       types.String `tfsdk:"type"`
+	Properties types.Map    `tfsdk:"properties"`
+}
+
+func (d *TablesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tables"
+}
+
+func (d *TablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("tables")
+	attributes["warehouse_id"] = schema.StringAttribute{
+		MarkdownDescription: "Warehouse ID",
+		Required:            true,
+	}
+	attributes["database"] = schema.StringAttribute{
+		MarkdownDescription: "Database Name",
+		Required:            true,
+	}
+	attributes["include_views"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether to list views as well as tables",
+		Optional:            true,
+	}
+	attributes["include_properties"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether to load each table to report its properties. This reads every table's metadata, " +
+			"so it's slower for large databases.",
+		Optional: true,
+	}
+	attributes["names"] = schema.ListAttribute{
+		MarkdownDescription: "Table names, sorted",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+	attributes["tables"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Tables, sorted by name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Table Name",
+					Computed:            true,
+				},
+				"identifier": schema.StringAttribute{
+					MarkdownDescription: "`database.name` identifier",
+					Computed:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "`TABLE` or `VIEW`",
+					Computed:            true,
+				},
+				"properties": schema.MapAttribute{
+					MarkdownDescription: "Table or view properties, null unless `include_properties` is set",
+					Computed:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the tables in a database through the Iceberg REST catalog",
+		Attributes:          attributes,
+	}
+}
+
+func (d *TablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TablesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newNameFilter(data.NamePrefix, data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name filter", err.Error())
+		return
+	}
+
+	warehouseId := data.WarehouseId.ValueString()
+	database := data.Database.ValueString()
+	tables, err := d.client.V1.ListTables(warehouseId, database)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing tables", fmt.Sprintf("Could not list tables in database %s: %s", database, err.Error()))
+		return
+	}
+	summaries := internal.Map(internal.Filter(tables, filter.matches), func(name string) TableSummaryModel {
+		return newTableSummary(database, name, tableTypeTable)
+	})
+
+	if data.IncludeViews.ValueBool() {
+		views, err := d.client.V1.ListViews(warehouseId, database)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing views", fmt.Sprintf("Could not list views in database %s: %s", database, err.Error()))
+			return
+		}
+		for _, view := range internal.Filter(views, filter.matches) {
+			summaries = append(summaries, newTableSummary(database, view, tableTypeView))
+		}
+	}
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].Name.ValueString() < summaries[j].Name.ValueString() })
+
+	if data.IncludeProperties.ValueBool() {
+		for i := range summaries {
+			d.readProperties(ctx, warehouseId, database, &summaries[i], &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	data.Tables = summaries
+	names, diags := types.ListValueFrom(ctx, types.StringType, internal.Map(summaries, func(s TableSummaryModel) string { return s.Name.ValueString() }))
+	resp.Diagnostics.Append(diags...)
+	data.Names = names
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *TablesDataSource) readProperties(ctx context.Context, warehouseId, database string, summary *TableSummaryModel, diags *diag.Diagnostics) {
+	name := summary.Name.ValueString()
+	var properties map[string]string
+	if summary.Type.ValueString() == tableTypeView {
+		view, err := d.client.V1.LoadView(warehouseId, database, name)
+		if err != nil {
+			diags.AddError("Error loading view", fmt.Sprintf("Could not load view %s.%s: %s", database, name, err.Error()))
+			return
+		}
+		// Dropped since it was listed
+		if view == nil {
+			return
+		}
+		properties = view.Metadata.Properties
+	} else {
+		table, err := d.client.V1.LoadTable(warehouseId, database, name)
+		if err != nil {
+			diags.AddError("Error loading table", fmt.Sprintf("Could not load table %s.%s: %s", database, name, err.Error()))
+			return
+		}
+		if table == nil {
+			return
+		}
+		properties = table.Metadata.Properties
+	}
+	if properties == nil {
+		properties = map[string]string{}
+	}
+
+	propertiesValue, propertyDiags := types.MapValueFrom(ctx, types.StringType, properties)
+	diags.Append(propertyDiags...)
+	summary.Properties = propertiesValue
+}
+
+func newTableSummary(database, name, tableType string) TableSummaryModel {
+	return TableSummaryModel{
+		Name:       types.StringValue(name),
+		Identifier: types.StringValue(database + "." + name),
+		Type:       types.StringValue(tableType),
+		Properties: types.MapNull(types.StringType),
+	}
+}
@@ -0,0 +1,39 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type Int64RangeValidator struct {
+	Min int64
+	Max int64
+}
+
+var (
+	_ validator.Int64 = &Int64RangeValidator{}
+)
+
+func (i Int64RangeValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Value must be between %d and %d", i.Min, i.Max)
+}
+
+func (i Int64RangeValidator) MarkdownDescription(ctx context.Context) string {
+	return i.Description(ctx)
+}
+
+func (i Int64RangeValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueInt64()
+	if value < i.Min || value > i.Max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid value",
+			fmt.Sprintf("%d is out of range. Value must be between %d and %d", value, i.Min, i.Max),
+		)
+	}
+}
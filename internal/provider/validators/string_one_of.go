@@ -0,0 +1,39 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"golang.org/x/exp/slices"
+)
+
+type StringOneOfValidator struct {
+	Values []string
+}
+
+var (
+	_ validator.String = &StringOneOfValidator{}
+)
+
+func (s StringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Value must be one of %s", s.Values)
+}
+
+func (s StringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return s.Description(ctx)
+}
+
+func (s StringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !slices.Contains(s.Values, value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid value",
+			fmt.Sprintf("%s is not a valid value. Valid values are %s", value, s.Values),
+		)
+	}
+}
@@ -0,0 +1,530 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/planmodifiers"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"golang.org/x/exp/slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	_ resource.Resource                   = &viewResource{}
+	_ resource.ResourceWithConfigure      = &viewResource{}
+	_ resource.ResourceWithImportState    = &viewResource{}
+	_ resource.ResourceWithValidateConfig = &viewResource{}
+)
+
+var viewDialects = []string{"spark", "trino"}
+
+type viewResource struct {
+	client *util.Client
+}
+
+func NewViewResource() resource.Resource {
+	return &viewResource{}
+}
+
+func (r *viewResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*util.Client)
+}
+
+type viewResourceModel struct {
+	Id               types.String      `tfsdk:"id"`
+	WarehouseId      types.String      `tfsdk:"warehouse_id"`
+	Database         types.String      `tfsdk:"database"`
+	Name             types.String      `tfsdk:"name"`
+	Sql              types.Map         `tfsdk:"sql"`
+	DefaultNamespace types.String      `tfsdk:"default_namespace"`
+	Columns          []viewColumnModel `tfsdk:"columns"`
+	Properties       types.Map         `tfsdk:"properties"`
+	ViewUuid         types.String      `tfsdk:"view_uuid"`
+	Location         types.String      `tfsdk:"location"`
+	MetadataLocation types.String      `tfsdk:"metadata_location"`
+	VersionId        types.Int64       `tfsdk:"version_id"`
+}
+
+type viewColumnModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Required types.Bool   `tfsdk:"required"`
+	Doc      types.String `tfsdk:"doc"`
+}
+
+func (r *viewResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_view"
+}
+
+func (r *viewResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An Iceberg view. Changing the SQL, default namespace or columns adds a new view version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "warehouseId/database/name",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"warehouse_id": schema.StringAttribute{
+				Description: "Warehouse ID (uuid)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Database Name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "View Name",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sql": schema.MapAttribute{
+				Description: "View SQL keyed by dialect, one of spark or trino",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"default_namespace": schema.StringAttribute{
+				Description: "Namespace unqualified table names in the SQL resolve against. Defaults to database.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"columns": schema.ListNestedAttribute{
+				Description: "Columns the SQL produces, in order",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Column Name",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Iceberg primitive type, e.g. long, string or decimal(10, 2)",
+							Required:    true,
+						},
+						"required": schema.BoolAttribute{
+							Description: "Whether the column can't be null",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.Bool{
+								planmodifiers.BoolDefaultModifier{Default: false},
+								boolplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"doc": schema.StringAttribute{
+							Description: "Column documentation",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"properties": schema.MapAttribute{
+				Description: "View properties. Properties set outside of Terraform are left alone.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"view_uuid": schema.StringAttribute{
+				Description: "View UUID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "Storage Location",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata_location": schema.StringAttribute{
+				Description: "Location of the view's current metadata file",
+				Computed:    true,
+			},
+			"version_id": schema.Int64Attribute{
+				Description: "Current view version",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *viewResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sql types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sql"), &sql)...)
+	if resp.Diagnostics.HasError() || sql.IsNull() || sql.IsUnknown() {
+		return
+	}
+	if len(sql.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("sql"), "Invalid view SQL", "At least one dialect's SQL must be set")
+	}
+	for dialect := range sql.Elements() {
+		if !slices.Contains(viewDialects, dialect) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sql"),
+				"Invalid view SQL",
+				fmt.Sprintf("%s is not a supported dialect. Supported dialects are %s", dialect, viewDialects),
+			)
+		}
+	}
+}
+
+func (r *viewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError("Could not parse ", "Expected warehouseId/database/view")
+		return
+	}
+
+	state := viewResourceModel{
+		Id:          types.StringValue(req.ID),
+		WarehouseId: types.StringValue(parts[0]),
+		Database:    types.StringValue(parts[1]),
+		Name:        types.StringValue(parts[2]),
+		Sql:         types.MapNull(types.StringType),
+		// Only the properties in the config are managed, so none are read back until the next apply
+		Properties: types.MapNull(types.StringType),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *viewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state viewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := state.Database.ValueString()
+	name := state.Name.ValueString()
+	view, err := r.client.V1.LoadView(state.WarehouseId.ValueString(), database, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error loading view", fmt.Sprintf("Could not load view %s.%s: %s", database, name, err.Error()))
+		return
+	}
+	if view == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err := setViewState(ctx, &state, view); err != nil {
+		resp.Diagnostics.AddError("View in unexpected state", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *viewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan viewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.DefaultNamespace.IsUnknown() {
+		plan.DefaultNamespace = plan.Database
+	}
+
+	schemaValue, version, err := newViewVersion(ctx, &plan, 0, 1)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid view", err.Error())
+		return
+	}
+	properties := make(map[string]string)
+	resp.Diagnostics.Append(plan.Properties.ElementsAs(ctx, &properties, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := plan.Database.ValueString()
+	name := plan.Name.ValueString()
+	view, err := r.client.V1.CreateView(plan.WarehouseId.ValueString(), database, tabular.CreateViewRequest{
+		Name:        name,
+		Schema:      *schemaValue,
+		ViewVersion: *version,
+		Properties:  properties,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating view", fmt.Sprintf("Could not create view %s.%s: %s", database, name, err.Error()))
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", plan.WarehouseId.ValueString(), database, name))
+	setViewComputed(&plan, view)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *viewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state viewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.DefaultNamespace.IsUnknown() {
+		plan.DefaultNamespace = plan.Database
+	}
+
+	warehouseId := state.WarehouseId.ValueString()
+	database := state.Database.ValueString()
+	name := state.Name.ValueString()
+	current, err := r.client.V1.LoadView(warehouseId, database, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error loading view", fmt.Sprintf("Could not load view %s.%s: %s", database, name, err.Error()))
+		return
+	}
+	if current == nil {
+		resp.Diagnostics.AddError("Error loading view", fmt.Sprintf("View %s.%s not found", database, name))
+		return
+	}
+
+	var updates []tabular.CatalogUpdate
+	if viewDefinitionChanged(&plan, &state) {
+		schemaId, versionId := 0, 0
+		for _, s := range current.Metadata.Schemas {
+			if s.SchemaId >= schemaId {
+				schemaId = s.SchemaId + 1
+			}
+		}
+		for _, v := range current.Metadata.Versions {
+			if v.VersionId >= versionId {
+				versionId = v.VersionId + 1
+			}
+		}
+		schemaValue, version, err := newViewVersion(ctx, &plan, schemaId, versionId)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid view", err.Error())
+			return
+		}
+		updates = append(updates,
+			tabular.CatalogUpdate{"action": "add-schema", "schema": schemaValue, "last-column-id": len(schemaValue.Fields)},
+			tabular.CatalogUpdate{"action": "add-view-version", "view-version": version},
+			tabular.CatalogUpdate{"action": "set-current-view-version", "view-version-id": version.VersionId},
+		)
+	}
+
+	var planProperties, stateProperties map[string]string
+	resp.Diagnostics.Append(plan.Properties.ElementsAs(ctx, &planProperties, false)...)
+	resp.Diagnostics.Append(state.Properties.ElementsAs(ctx, &stateProperties, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updates = append(updates, propertyUpdates(stateProperties, planProperties)...)
+
+	if len(updates) > 0 {
+		view, err := r.client.V1.CommitView(warehouseId, database, name, tabular.CommitViewRequest{
+			Requirements: []tabular.CatalogRequirement{tabular.AssertViewUuid(state.ViewUuid.ValueString())},
+			Updates:      updates,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating view", fmt.Sprintf("Could not update view %s.%s: %s", database, name, err.Error()))
+			return
+		}
+		current = view
+	}
+
+	setViewComputed(&plan, current)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *viewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state viewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	database := state.Database.ValueString()
+	name := state.Name.ValueString()
+	err := r.client.V1.DropView(state.WarehouseId.ValueString(), database, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error dropping view", fmt.Sprintf("Could not drop view %s.%s: %s", database, name, err.Error()))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// newViewVersion builds the schema and view version for the SQL, default namespace and columns in the model
+func newViewVersion(ctx context.Context, data *viewResourceModel, schemaId, versionId int) (*tabular.Schema, *tabular.ViewVersion, error) {
+	schemaValue := &tabular.Schema{Type: "struct", SchemaId: schemaId, Fields: make([]tabular.SchemaField, 0, len(data.Columns))}
+	for i, column := range data.Columns {
+		fieldType, err := json.Marshal(column.Type.ValueString())
+		if err != nil {
+			return nil, nil, err
+		}
+		schemaValue.Fields = append(schemaValue.Fields, tabular.SchemaField{
+			Id:       i + 1,
+			Name:     column.Name.ValueString(),
+			Required: column.Required.ValueBool(),
+			Type:     fieldType,
+			Doc:      column.Doc.ValueString(),
+		})
+	}
+
+	sql := make(map[string]string)
+	if diags := data.Sql.ElementsAs(ctx, &sql, false); diags.HasError() {
+		return nil, nil, fmt.Errorf("could not read sql")
+	}
+	dialects := make([]string, 0, len(sql))
+	for dialect := range sql {
+		dialects = append(dialects, dialect)
+	}
+	sort.Strings(dialects)
+
+	version := &tabular.ViewVersion{
+		VersionId:   versionId,
+		SchemaId:    schemaId,
+		TimestampMs: time.Now().UnixMilli(),
+		Summary:     map[string]string{"engine-name": "terraform"},
+		Representations: internal.Map(dialects, func(dialect string) tabular.ViewRepresentation {
+			return tabular.ViewRepresentation{Type: "sql", Sql: sql[dialect], Dialect: dialect}
+		}),
+		DefaultNamespace: strings.Split(data.DefaultNamespace.ValueString(), "."),
+	}
+	return schemaValue, version, nil
+}
+
+// viewDefinitionChanged reports whether anything stored in a view version differs between the plan and state
+func viewDefinitionChanged(plan, state *viewResourceModel) bool {
+	if !plan.Sql.Equal(state.Sql) || !plan.DefaultNamespace.Equal(state.DefaultNamespace) || len(plan.Columns) != len(state.Columns) {
+		return true
+	}
+	for i := range plan.Columns {
+		p, s := plan.Columns[i], state.Columns[i]
+		if !p.Name.Equal(s.Name) || !p.Type.Equal(s.Type) || !p.Required.Equal(s.Required) || p.Doc.ValueString() != s.Doc.ValueString() {
+			return true
+		}
+	}
+	return false
+}
+
+// propertyUpdates returns the updates that turn the current properties into the wanted ones
+func propertyUpdates(current, wanted map[string]string) []tabular.CatalogUpdate {
+	var updates []tabular.CatalogUpdate
+	changed, removed := diffProperties(current, wanted)
+	if len(changed) > 0 {
+		updates = append(updates, tabular.SetPropertiesUpdate(changed))
+	}
+	if len(removed) > 0 {
+		updates = append(updates, tabular.RemovePropertiesUpdate(removed))
+	}
+	return updates
+}
+
+// diffProperties returns the properties to set and, sorted, the ones to remove to turn current into wanted
+func diffProperties(current, wanted map[string]string) (map[string]string, []string) {
+	changed := make(map[string]string)
+	for key, value := range wanted {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			changed[key] = value
+		}
+	}
+
+	var removed []string
+	for key := range current {
+		if _, ok := wanted[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	return changed, removed
+}
+
+func setViewComputed(data *viewResourceModel, view *tabular.LoadViewResult) {
+	data.ViewUuid = types.StringValue(view.Metadata.ViewUuid)
+	data.Location = types.StringValue(view.Metadata.Location)
+	data.MetadataLocation = types.StringValue(view.MetadataLocation)
+	data.VersionId = types.Int64Value(int64(view.Metadata.CurrentVersionId))
+}
+
+// setViewState refreshes data from the view's current version. Only the properties already in data are kept, unless
+// they're unknown after an import.
+func setViewState(ctx context.Context, data *viewResourceModel, view *tabular.LoadViewResult) error {
+	version := view.Metadata.CurrentVersion()
+	if version == nil {
+		return fmt.Errorf("view has no version with the current version id %d", view.Metadata.CurrentVersionId)
+	}
+	viewSchema := view.Metadata.Schema(version.SchemaId)
+	if viewSchema == nil {
+		return fmt.Errorf("view has no schema with id %d", version.SchemaId)
+	}
+
+	setViewComputed(data, view)
+	if data.Id.IsNull() || data.Id.IsUnknown() {
+		data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", data.WarehouseId.ValueString(), data.Database.ValueString(), data.Name.ValueString()))
+	}
+
+	sql := make(map[string]string)
+	for _, representation := range version.Representations {
+		if representation.Type == "sql" {
+			sql[strings.ToLower(representation.Dialect)] = representation.Sql
+		}
+	}
+	sqlValue, diags := types.MapValueFrom(ctx, types.StringType, sql)
+	if diags.HasError() {
+		return fmt.Errorf("could not read view sql")
+	}
+	data.Sql = sqlValue
+	data.DefaultNamespace = types.StringValue(strings.Join(version.DefaultNamespace, "."))
+
+	data.Columns = make([]viewColumnModel, 0, len(viewSchema.Fields))
+	for _, field := range viewSchema.Fields {
+		column := viewColumnModel{
+			Name:     types.StringValue(field.Name),
+			Type:     types.StringValue(icebergTypeString(field.Type)),
+			Required: types.BoolValue(field.Required),
+			Doc:      types.StringNull(),
+		}
+		if field.Doc != "" {
+			column.Doc = types.StringValue(field.Doc)
+		}
+		data.Columns = append(data.Columns, column)
+	}
+
+	// Only properties Terraform manages are read back
+	properties := make(map[string]string)
+	for key := range data.Properties.Elements() {
+		if value, ok := view.Metadata.Properties[key]; ok {
+			properties[key] = value
+		}
+	}
+	if data.Properties.IsNull() {
+		data.Properties = types.MapNull(types.StringType)
+	} else {
+		propertiesValue, diags := types.MapValueFrom(ctx, types.StringType, properties)
+		if diags.HasError() {
+			return fmt.Errorf("could not read view properties")
+		}
+		data.Properties = propertiesValue
+	}
+	return nil
+}
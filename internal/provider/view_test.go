@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+)
+
+const testViewMetadata = `{
+  "metadata-location": "s3://bucket/db/daily_events/metadata/00002.metadata.json",
+  "metadata": {
+    "view-uuid": "fa6506c3-7681-40c8-86dc-e36561f83385",
+    "format-version": 1,
+    "location": "s3://bucket/db/daily_events",
+    "current-version-id": 2,
+    "versions": [
+      {"version-id": 1, "schema-id": 0, "timestamp-ms": 1700000000000, "summary": {},
+       "representations": [{"type": "sql", "sql": "SELECT 1 AS id", "dialect": "spark"}], "default-namespace": ["db"]},
+      {"version-id": 2, "schema-id": 1, "timestamp-ms": 1700000001000, "summary": {},
+       "representations": [
+         {"type": "sql", "sql": "SELECT id, day FROM events", "dialect": "Spark"},
+         {"type": "sql", "sql": "SELECT id, day FROM events", "dialect": "trino"}
+       ],
+       "default-namespace": ["prod", "db"]}
+    ],
+    "schemas": [
+      {"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "id", "required": false, "type": "int"}]},
+      {"type": "struct", "schema-id": 1, "fields": [
+        {"id": 1, "name": "id", "required": true, "type": "long", "doc": "Event id"},
+        {"id": 2, "name": "day", "required": false, "type": "date"}
+      ]}
+    ],
+    "properties": {"comment": "Daily events", "owner": "etl"}
+  }
+}`
+
+func TestSetViewState(t *testing.T) {
+	var view tabular.LoadViewResult
+	assert.NoError(t, json.Unmarshal([]byte(testViewMetadata), &view))
+
+	data := viewResourceModel{
+		WarehouseId: types.StringValue("wh"),
+		Database:    types.StringValue("db"),
+		Name:        types.StringValue("daily_events"),
+		Properties:  types.MapValueMust(types.StringType, map[string]attr.Value{"comment": types.StringValue("old")}),
+	}
+	assert.NoError(t, setViewState(context.Background(), &data, &view))
+
+	assert.Equal(t, "wh/db/daily_events", data.Id.ValueString())
+	assert.Equal(t, "fa6506c3-7681-40c8-86dc-e36561f83385", data.ViewUuid.ValueString())
+	assert.Equal(t, int64(2), data.VersionId.ValueInt64())
+	assert.Equal(t, "prod.db", data.DefaultNamespace.ValueString())
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+		"spark": types.StringValue("SELECT id, day FROM events"),
+		"trino": types.StringValue("SELECT id, day FROM events"),
+	}), data.Sql)
+	assert.Equal(t, []viewColumnModel{
+		{Name: types.StringValue("id"), Type: types.StringValue("long"), Required: types.BoolValue(true), Doc: types.StringValue("Event id")},
+		{Name: types.StringValue("day"), Type: types.StringValue("date"), Required: types.BoolValue(false), Doc: types.StringNull()},
+	}, data.Columns)
+	// Only properties Terraform manages are read back
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{"comment": types.StringValue("Daily events")}), data.Properties)
+
+	// After an import no properties are managed until the config sets some
+	data.Properties = types.MapNull(types.StringType)
+	assert.NoError(t, setViewState(context.Background(), &data, &view))
+	assert.True(t, data.Properties.IsNull())
+}
+
+func TestViewDefinitionChanged(t *testing.T) {
+	state := viewResourceModel{
+		Sql:              types.MapValueMust(types.StringType, map[string]attr.Value{"spark": types.StringValue("SELECT 1 AS id")}),
+		DefaultNamespace: types.StringValue("db"),
+		Columns:          []viewColumnModel{{Name: types.StringValue("id"), Type: types.StringValue("int"), Required: types.BoolValue(false), Doc: types.StringNull()}},
+	}
+	plan := state
+	plan.Columns = []viewColumnModel{state.Columns[0]}
+	assert.False(t, viewDefinitionChanged(&plan, &state))
+
+	plan.Columns[0].Doc = types.StringValue("Identifier")
+	assert.True(t, viewDefinitionChanged(&plan, &state))
+
+	plan = state
+	plan.Sql = types.MapValueMust(types.StringType, map[string]attr.Value{"spark": types.StringValue("SELECT 2 AS id")})
+	assert.True(t, viewDefinitionChanged(&plan, &state))
+}
+
+func TestPropertyUpdates(t *testing.T) {
+	assert.Empty(t, propertyUpdates(map[string]string{"a": "1"}, map[string]string{"a": "1"}))
+	assert.Equal(t, []tabular.CatalogUpdate{
+		tabular.SetPropertiesUpdate(map[string]string{"a": "2", "c": "3"}),
+		tabular.RemovePropertiesUpdate([]string{"b"}),
+	}, propertyUpdates(map[string]string{"a": "1", "b": "1"}, map[string]string{"a": "2", "c": "3"}))
+}
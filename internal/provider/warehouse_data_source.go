@@ -7,8 +7,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
 	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
-	"github.com/tabular-io/terraform-provider-tabular/internal/tabular"
+	"strings"
 )
 
 var _ datasource.DataSource = &WarehouseDataSource{}
@@ -28,6 +30,7 @@ type WarehouseDataSourceModel struct {
 	OrganizationId types.String `tfsdk:"organization_id"`
 	StorageProfile types.String `tfsdk:"storage_profile"`
 	Region         types.String `tfsdk:"region"`
+	IgnoreCase     types.Bool   `tfsdk:"ignore_case"`
 }
 
 func (d *WarehouseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -41,11 +44,17 @@ func (d *WarehouseDataSource) Schema(ctx context.Context, req datasource.SchemaR
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Warehouse ID",
+				MarkdownDescription: "Warehouse ID. Exactly one of `id` or `name` must be set.",
 				Optional:            true,
+				Computed:            true,
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Warehouse Name",
+				MarkdownDescription: "Warehouse Name. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"ignore_case": schema.BoolAttribute{
+				MarkdownDescription: "Match `name` case-insensitively when no warehouse has exactly that name",
 				Optional:            true,
 			},
 			"organization_id": schema.StringAttribute{
@@ -91,63 +100,92 @@ func (d *WarehouseDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	GetWarehouseByIdOrName(ctx, *d.client, &data, resp)
+	configuredName := data.Name
+	GetWarehouseByIdOrName(ctx, *d.client, &data, data.IgnoreCase.ValueBool(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// A case-insensitive match has to report the name as configured
+	if !configuredName.IsNull() {
+		data.Name = configuredName
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func GetWarehouseByIdOrName(ctx context.Context, client util.Client, data *WarehouseDataSourceModel, resp *datasource.ReadResponse) {
+// GetWarehouseByIdOrName fills in data for the warehouse with data.Id, or when that's null, the warehouse named
+// data.Name
+func GetWarehouseByIdOrName(ctx context.Context, client util.Client, data *WarehouseDataSourceModel, ignoreCase bool, diags *diag.Diagnostics) {
+	if data.Id.IsNull() == data.Name.IsNull() {
+		diags.AddError("Invalid warehouse", "Exactly one of the warehouse id or name must be set")
+		return
+	}
+
+	warehouseId := data.Id.ValueString()
 	if data.Id.IsNull() {
-		getWarehouseByName(*client.V1, data, resp.Diagnostics)
-	} else {
-		warehouseId := data.Id.ValueString()
-		warehouse, _, err := client.V2.DefaultAPI.GetWarehouse(ctx, *client.OrganizationId, warehouseId).Execute()
+		warehouse, err := findWarehouseByName(ctx, &client, data.Name.ValueString(), ignoreCase)
 		if err != nil {
-			resp.Diagnostics.AddError("Warehouse not found", err.Error())
+			diags.AddError("Warehouse not found", err.Error())
 			return
 		}
+		warehouseId = warehouse.GetId()
+	}
 
-		if name, ok := warehouse.GetNameOk(); ok {
-			data.Name = types.StringValue(*name)
-		} else {
-			data.Name = types.StringNull()
-		}
+	retryFunc := util.RetryResourceResponse[*tabularv2.GetWarehouseResponse]
+	warehouse, _, err := retryFunc(client.V2.DefaultAPI.GetWarehouse(ctx, *client.OrganizationId, warehouseId).Execute)
+	if err != nil {
+		diags.AddError("Warehouse not found", err.Error())
+		return
+	}
 
-		if orgId, ok := warehouse.GetOrganizationIdOk(); ok {
-			data.OrganizationId = types.StringValue(*orgId)
-		} else {
-			data.OrganizationId = types.StringNull()
-		}
+	data.Id = types.StringValue(warehouseId)
 
-		if storageProfile, ok := warehouse.GetStorageProfileOk(); ok {
-			data.StorageProfile = types.StringValue(*storageProfile)
-		} else {
-			data.StorageProfile = types.StringNull()
-		}
+	if name, ok := warehouse.GetNameOk(); ok {
+		data.Name = types.StringValue(*name)
+	} else {
+		data.Name = types.StringNull()
+	}
 
-		if region, ok := warehouse.GetRegionOk(); ok {
-			data.Region = types.StringValue(*region)
-		} else {
-			data.Region = types.StringNull()
-		}
+	if orgId, ok := warehouse.GetOrganizationIdOk(); ok {
+		data.OrganizationId = types.StringValue(*orgId)
+	} else {
+		data.OrganizationId = types.StringNull()
+	}
+
+	if storageProfile, ok := warehouse.GetStorageProfileOk(); ok {
+		data.StorageProfile = types.StringValue(*storageProfile)
+	} else {
+		data.StorageProfile = types.StringNull()
+	}
+
+	if region, ok := warehouse.GetRegionOk(); ok {
+		data.Region = types.StringValue(*region)
+	} else {
+		data.Region = types.StringNull()
 	}
 }
 
-func getWarehouseByName(client tabular.Client, data *WarehouseDataSourceModel, diag diag.Diagnostics) {
-	warehouses, err := client.GetWarehouses()
+// findWarehouseByName returns the warehouse called name, failing when no warehouse or more than one has that name
+func findWarehouseByName(ctx context.Context, client *util.Client, name string, ignoreCase bool) (*tabularv2.Warehouse, error) {
+	retryFunc := util.RetryResourceResponse[*tabularv2.ListWarehouseResponse]
+	listResp, _, err := retryFunc(client.V2.DefaultAPI.ListWarehouses(ctx, *client.OrganizationId).Execute)
 	if err != nil {
-		diag.AddError("Failed fetching warehouses", err.Error())
+		return nil, fmt.Errorf("could not list warehouses: %w", err)
 	}
 
-	targetName := data.Name.ValueString()
-	if targetName != "" {
-		for _, w := range warehouses {
-			if w.Name == targetName {
-				data.Id = types.StringValue(w.Id)
-				data.Region = types.StringValue(w.Region)
-				break
-			}
-		}
-	}
+	return matchWarehouseName(listResp.Warehouses, name, ignoreCase)
+}
 
-	diag.AddError("Warehouse not found", fmt.Sprintf("Could not find warehouse with name %s", targetName))
+func matchWarehouseName(warehouses []tabularv2.Warehouse, name string, ignoreCase bool) (*tabularv2.Warehouse, error) {
+	matches := internal.Filter(warehouses, func(w tabularv2.Warehouse) bool { return w.GetName() == name })
+	if ignoreCase && len(matches) == 0 {
+		matches = internal.Filter(warehouses, func(w tabularv2.Warehouse) bool { return strings.EqualFold(w.GetName(), name) })
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("could not find warehouse with name %s", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d warehouses are named %s, use the warehouse id instead", len(matches), name)
+	}
 }
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
 )
 
 func TestAccWarehouseDataSourceByName(t *testing.T) {
@@ -23,6 +25,9 @@ func TestAccWarehouseDataSourceByName(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.tabular_warehouse.test", "name", name),
 					resource.TestCheckResourceAttr("data.tabular_warehouse.test", "region", "us-west-2"),
+					resource.TestCheckResourceAttrPair("data.tabular_warehouse.test", "id", "tabular_warehouse.test", "id"),
+					resource.TestCheckResourceAttrPair("data.tabular_warehouse.test", "storage_profile", "tabular_s3_storage_profile.test", "id"),
+					resource.TestCheckResourceAttrSet("data.tabular_warehouse.test", "organization_id"),
 				),
 			},
 		},
@@ -87,3 +92,35 @@ data "tabular_warehouse" "test" {
 }
 `, bucketName, roleArn, name)
 }
+
+func TestMatchWarehouseName(t *testing.T) {
+	warehouses := []tabularv2.Warehouse{
+		{Id: tabularv2.PtrString("1"), Name: tabularv2.PtrString("prod")},
+		{Id: tabularv2.PtrString("2"), Name: tabularv2.PtrString("Prod")},
+		{Id: tabularv2.PtrString("3"), Name: tabularv2.PtrString("dev")},
+		{Id: tabularv2.PtrString("4"), Name: tabularv2.PtrString("shared")},
+		{Id: tabularv2.PtrString("5"), Name: tabularv2.PtrString("shared")},
+	}
+
+	warehouse, err := matchWarehouseName(warehouses, "Prod", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", warehouse.GetId())
+
+	_, err = matchWarehouseName(warehouses, "DEV", false)
+	assert.ErrorContains(t, err, "could not find warehouse")
+
+	warehouse, err = matchWarehouseName(warehouses, "DEV", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", warehouse.GetId())
+
+	// An exact match wins over the other case-insensitive ones
+	warehouse, err = matchWarehouseName(warehouses, "prod", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", warehouse.GetId())
+
+	_, err = matchWarehouseName(warehouses, "PROD", true)
+	assert.ErrorContains(t, err, "2 warehouses are named PROD")
+
+	_, err = matchWarehouseName(warehouses, "shared", false)
+	assert.ErrorContains(t, err, "2 warehouses are named shared")
+}
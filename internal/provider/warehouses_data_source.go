@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tabularv2 "github.com/tabular-io/tabular-sdk-go/tabular"
+	"github.com/tabular-io/terraform-provider-tabular/internal"
+	"github.com/tabular-io/terraform-provider-tabular/internal/provider/util"
+	"sort"
+)
+
+var _ datasource.DataSource = &WarehousesDataSource{}
+var _ datasource.DataSourceWithConfigure = &WarehousesDataSource{}
+
+func NewWarehousesDataSource() datasource.DataSource {
+	return &WarehousesDataSource{}
+}
+
+type WarehousesDataSource struct {
+	client *util.Client
+}
+
+type WarehousesDataSourceModel struct {
+	NamePrefix types.String            `tfsdk:"name_prefix"`
+	NameRegex  types.String            `tfsdk:"name_regex"`
+	Ids        types.List              `tfsdk:"ids"`
+	Names      types.List              `tfsdk:"names"`
+	Warehouses []WarehouseSummaryModel `tfsdk:"warehouses"`
+}
+
+type WarehouseSummaryModel struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Region         types.String `tfsdk:"region"`
+	StorageProfile types.String `tfsdk:"storage_profile"`
+}
+
+func (d *WarehousesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouses"
+}
+
+func (d *WarehousesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("warehouses")
+	attributes["ids"] = schema.ListAttribute{
+		MarkdownDescription: "Warehouse IDs, in the same order as `names`",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+	attributes["names"] = schema.ListAttribute{
+		MarkdownDescription: "Warehouse names, sorted",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+	attributes["warehouses"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Warehouses, sorted by name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Warehouse ID",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Warehouse Name",
+					Computed:            true,
+				},
+				"region": schema.StringAttribute{
+					MarkdownDescription: "Warehouse Region",
+					Computed:            true,
+				},
+				"storage_profile": schema.StringAttribute{
+					MarkdownDescription: "Storage Profile ID",
+					Computed:            true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the warehouses in the organization",
+		Attributes:          attributes,
+	}
+}
+
+func (d *WarehousesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*util.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *util.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WarehousesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WarehousesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := newNameFilter(data.NamePrefix, data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name filter", err.Error())
+		return
+	}
+
+	retryFunc := util.RetryResourceResponse[*tabularv2.ListWarehouseResponse]
+	listResp, _, err := retryFunc(d.client.V2.DefaultAPI.ListWarehouses(ctx, *d.client.OrganizationId).Execute)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing warehouses", err.Error())
+		return
+	}
+	warehouses := internal.Filter(listResp.Warehouses, func(w tabularv2.Warehouse) bool { return filter.matches(w.GetName()) })
+	sort.Slice(warehouses, func(i, j int) bool { return warehouses[i].GetName() < warehouses[j].GetName() })
+
+	data.Warehouses = internal.Map(warehouses, func(w tabularv2.Warehouse) WarehouseSummaryModel {
+		summary := WarehouseSummaryModel{
+			Id:             types.StringValue(w.GetId()),
+			Name:           types.StringValue(w.GetName()),
+			Region:         types.StringValue(w.GetRegion()),
+			StorageProfile: types.StringNull(),
+		}
+		if storageProfile, ok := w.GetStorageProfileOk(); ok {
+			summary.StorageProfile = types.StringValue(*storageProfile)
+		}
+		return summary
+	})
+	ids, diags := types.ListValueFrom(ctx, types.StringType, internal.Map(warehouses, func(w tabularv2.Warehouse) string { return w.GetId() }))
+	resp.Diagnostics.Append(diags...)
+	data.Ids = ids
+	names, diags := types.ListValueFrom(ctx, types.StringType, internal.Map(warehouses, func(w tabularv2.Warehouse) string { return w.GetName() }))
+	resp.Diagnostics.Append(diags...)
+	data.Names = names
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+package tabular
+
+// CatalogUpdate is one change in an Iceberg REST commit, e.g. {"action": "set-properties", "updates": {...}}
+type CatalogUpdate map[string]interface{}
+
+// CatalogRequirement is a precondition an Iceberg REST commit is checked against, e.g.
+// {"type": "assert-view-uuid", "uuid": "..."}
+type CatalogRequirement map[string]interface{}
+
+func SetPropertiesUpdate(updates map[string]string) CatalogUpdate {
+	return CatalogUpdate{"action": "set-properties", "updates": updates}
+}
+
+func RemovePropertiesUpdate(removals []string) CatalogUpdate {
+	return CatalogUpdate{"action": "remove-properties", "removals": removals}
+}
+
+// SetSnapshotRefUpdate creates or moves a branch or tag. Retention settings left nil fall back to the table's defaults.
+func SetSnapshotRefUpdate(name string, ref SnapshotRef) CatalogUpdate {
+	update := CatalogUpdate{"action": "set-snapshot-ref", "ref-name": name, "type": ref.Type, "snapshot-id": ref.SnapshotId}
+	if ref.MaxRefAgeMs != nil {
+		update["max-ref-age-ms"] = *ref.MaxRefAgeMs
+	}
+	if ref.MaxSnapshotAgeMs != nil {
+		update["max-snapshot-age-ms"] = *ref.MaxSnapshotAgeMs
+	}
+	if ref.MinSnapshotsToKeep != nil {
+		update["min-snapshots-to-keep"] = *ref.MinSnapshotsToKeep
+	}
+	return update
+}
+
+func RemoveSnapshotRefUpdate(name string) CatalogUpdate {
+	return CatalogUpdate{"action": "remove-snapshot-ref", "ref-name": name}
+}
+
+func AssertTableUuid(uuid string) CatalogRequirement {
+	return CatalogRequirement{"type": "assert-table-uuid", "uuid": uuid}
+}
+
+func AssertViewUuid(uuid string) CatalogRequirement {
+	return CatalogRequirement{"type": "assert-view-uuid", "uuid": uuid}
+}
+
+// AssertRefSnapshotId requires a branch or tag to point at snapshotId, or not to exist when snapshotId is nil
+func AssertRefSnapshotId(ref string, snapshotId *int64) CatalogRequirement {
+	return CatalogRequirement{"type": "assert-ref-snapshot-id", "ref": ref, "snapshot-id": snapshotId}
+}
@@ -0,0 +1,49 @@
+package tabular
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const ServiceAccountCredentialType = "SERVICE"
+
+func (c *Client) GetServiceAccounts() ([]Credential, error) {
+	credentials, err := c.getCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts := make([]Credential, 0, len(credentials))
+	for _, credential := range credentials {
+		if credential.Type == ServiceAccountCredentialType {
+			serviceAccounts = append(serviceAccounts, credential)
+		}
+	}
+	return serviceAccounts, nil
+}
+
+func (c *Client) getCredentials() ([]Credential, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/ws/v1/iam/credentials", c.Endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		clientErr, ok := err.(*ClientError)
+		if ok && clientErr.response.StatusCode == 404 {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	var credentials []Credential
+	err = json.Unmarshal(body, &credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
@@ -5,12 +5,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 type databaseRequest struct {
 	Namespace []string `json:"namespace"`
 }
 
+type namespaceResponse struct {
+	Namespace  []string          `json:"namespace"`
+	Properties map[string]string `json:"properties"`
+}
+
+type updateNamespacePropertiesRequest struct {
+	Removals []string          `json:"removals"`
+	Updates  map[string]string `json:"updates"`
+}
+
+type listNamespacesResponse struct {
+	Namespaces    [][]string `json:"namespaces"`
+	NextPageToken string     `json:"next-page-token"`
+}
+
 func (c *Client) GetDatabase(warehouseId, namespace string) (*Database, error) {
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/ws/v1/warehouses/%s/namespaces/%s/ext", c.Endpoint, warehouseId, namespace), nil)
 	if err != nil {
@@ -79,3 +96,93 @@ func (c *Client) DeleteDatabase(warehouseId, namespace string) (err error) {
 
 	return
 }
+
+// ListDatabases returns the names of the databases in a warehouse, following pagination until every page is read
+func (c *Client) ListDatabases(warehouseId string) ([]string, error) {
+	databases := make([]string, 0)
+	pageToken := ""
+	for {
+		endpoint := fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces", c.Endpoint, warehouseId)
+		if pageToken != "" {
+			endpoint += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := c.doRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var page listNamespacesResponse
+		err = json.Unmarshal(body, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range page.Namespaces {
+			if len(namespace) > 0 {
+				databases = append(databases, strings.Join(namespace, "."))
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return databases, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// GetDatabaseProperties returns a database's properties from the Iceberg REST catalog, or nil when it doesn't exist
+func (c *Client) GetDatabaseProperties(warehouseId, namespace string) (map[string]string, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces/%s", c.Endpoint, warehouseId, url.PathEscape(namespace)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		clientErr, ok := err.(*ClientError)
+		if ok && clientErr.response.StatusCode == 404 {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	var namespaceResp namespaceResponse
+	err = json.Unmarshal(body, &namespaceResp)
+	if err != nil {
+		return nil, err
+	}
+	if namespaceResp.Properties == nil {
+		namespaceResp.Properties = make(map[string]string)
+	}
+
+	return namespaceResp.Properties, nil
+}
+
+func (c *Client) UpdateDatabaseProperties(warehouseId, namespace string, removals []string, updates map[string]string) error {
+	if removals == nil {
+		removals = []string{}
+	}
+	if updates == nil {
+		updates = map[string]string{}
+	}
+	reqBody, err := json.Marshal(updateNamespacePropertiesRequest{Removals: removals, Updates: updates})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces/%s/properties", c.Endpoint, warehouseId, url.PathEscape(namespace)),
+		bytes.NewReader(reqBody),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	return err
+}
@@ -5,21 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
+// GetOrgMemberIdsMap maps the lowercased email of every org member to their member id. Emails are
+// case-insensitive, so lookups should lowercase the email too.
 func (c *Client) GetOrgMemberIdsMap() (map[string]string, error) {
-	orgMembers, err := c.getOrgMembers()
+	orgMembers, err := c.GetOrgMembers()
 	if err != nil {
 		return nil, err
 	}
 	orgMemberMap := make(map[string]string, len(orgMembers))
 	for _, orgMember := range orgMembers {
-		orgMemberMap[orgMember.Email] = orgMember.Id
+		orgMemberMap[strings.ToLower(orgMember.Email)] = orgMember.Id
 	}
 	return orgMemberMap, nil
 }
 
-func (c *Client) getOrgMembers() ([]Member, error) {
+func (c *Client) GetOrgMembers() ([]Member, error) {
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/ws/v1/grants/members", c.Endpoint), nil)
 	if err != nil {
 		return nil, err
@@ -30,6 +30,15 @@ type (
 		WithAdmin bool
 	}
 
+	Credential struct {
+		Id       string
+		Key      string
+		MemberId string
+		RoleId   string
+		Name     string
+		Type     string
+	}
+
 	RoleRelation struct {
 		ParentRoleId string
 		ChildRoleId  string
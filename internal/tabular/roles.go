@@ -46,6 +46,26 @@ func (c *Client) GetRole(roleName string) (*Role, error) {
 	return &role, nil
 }
 
+func (c *Client) ListRoles() ([]Role, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/ws/v1/grants/roles", c.Endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []Role
+	err = json.Unmarshal(body, &roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
 func (c *Client) CreateRole(name string) (*Role, error) {
 	reqBody, err := json.Marshal(CreateRoleRequest{
 		RoleName: name,
@@ -0,0 +1,240 @@
+package tabular
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type tableIdentifier struct {
+	Namespace []string `json:"namespace"`
+	Name      string   `json:"name"`
+}
+
+type listIdentifiersResponse struct {
+	Identifiers   []tableIdentifier `json:"identifiers"`
+	NextPageToken string            `json:"next-page-token"`
+}
+
+// LoadTableResult is the Iceberg REST catalog's response to loading a table
+type LoadTableResult struct {
+	MetadataLocation string            `json:"metadata-location"`
+	Metadata         TableMetadata     `json:"metadata"`
+	Config           map[string]string `json:"config"`
+}
+
+type TableMetadata struct {
+	FormatVersion      int                    `json:"format-version"`
+	TableUuid          string                 `json:"table-uuid"`
+	Location           string                 `json:"location"`
+	CurrentSchemaId    int                    `json:"current-schema-id"`
+	Schemas            []Schema               `json:"schemas"`
+	DefaultSpecId      int                    `json:"default-spec-id"`
+	PartitionSpecs     []PartitionSpec        `json:"partition-specs"`
+	DefaultSortOrderId int                    `json:"default-sort-order-id"`
+	SortOrders         []SortOrder            `json:"sort-orders"`
+	Properties         map[string]string      `json:"properties"`
+	CurrentSnapshotId  *int64                 `json:"current-snapshot-id"`
+	Snapshots          []Snapshot             `json:"snapshots"`
+	Refs               map[string]SnapshotRef `json:"refs"`
+}
+
+type CommitTableRequest struct {
+	Requirements []CatalogRequirement `json:"requirements"`
+	Updates      []CatalogUpdate      `json:"updates"`
+}
+
+// Schema is an Iceberg struct schema. Field types are either a primitive type name or a nested struct, list or map
+// type, so they're kept as raw JSON.
+type Schema struct {
+	Type               string        `json:"type"`
+	SchemaId           int           `json:"schema-id"`
+	IdentifierFieldIds []int         `json:"identifier-field-ids,omitempty"`
+	Fields             []SchemaField `json:"fields"`
+}
+
+type SchemaField struct {
+	Id       int             `json:"id"`
+	Name     string          `json:"name"`
+	Required bool            `json:"required"`
+	Type     json.RawMessage `json:"type"`
+	Doc      string          `json:"doc,omitempty"`
+}
+
+type PartitionSpec struct {
+	SpecId int              `json:"spec-id"`
+	Fields []PartitionField `json:"fields"`
+}
+
+type PartitionField struct {
+	SourceId  int    `json:"source-id"`
+	FieldId   int    `json:"field-id"`
+	Name      string `json:"name"`
+	Transform string `json:"transform"`
+}
+
+type SortOrder struct {
+	OrderId int         `json:"order-id"`
+	Fields  []SortField `json:"fields"`
+}
+
+type SortField struct {
+	SourceId  int    `json:"source-id"`
+	Transform string `json:"transform"`
+	Direction string `json:"direction"`
+	NullOrder string `json:"null-order"`
+}
+
+type Snapshot struct {
+	SnapshotId       int64             `json:"snapshot-id"`
+	ParentSnapshotId *int64            `json:"parent-snapshot-id,omitempty"`
+	SequenceNumber   int64             `json:"sequence-number"`
+	TimestampMs      int64             `json:"timestamp-ms"`
+	ManifestList     string            `json:"manifest-list"`
+	Summary          map[string]string `json:"summary"`
+	SchemaId         *int              `json:"schema-id,omitempty"`
+}
+
+type SnapshotRef struct {
+	SnapshotId         int64  `json:"snapshot-id"`
+	Type               string `json:"type"`
+	MaxRefAgeMs        *int64 `json:"max-ref-age-ms,omitempty"`
+	MaxSnapshotAgeMs   *int64 `json:"max-snapshot-age-ms,omitempty"`
+	MinSnapshotsToKeep *int   `json:"min-snapshots-to-keep,omitempty"`
+}
+
+// CurrentSchema returns the schema with the current schema id, or nil when the metadata doesn't have it
+func (m *TableMetadata) CurrentSchema() *Schema {
+	for i := range m.Schemas {
+		if m.Schemas[i].SchemaId == m.CurrentSchemaId {
+			return &m.Schemas[i]
+		}
+	}
+	return nil
+}
+
+// CurrentSnapshot returns the table's current snapshot, or nil when the table has none
+func (m *TableMetadata) CurrentSnapshot() *Snapshot {
+	if m.CurrentSnapshotId == nil {
+		return nil
+	}
+	for i := range m.Snapshots {
+		if m.Snapshots[i].SnapshotId == *m.CurrentSnapshotId {
+			return &m.Snapshots[i]
+		}
+	}
+	return nil
+}
+
+func (m *TableMetadata) DefaultPartitionSpec() *PartitionSpec {
+	for i := range m.PartitionSpecs {
+		if m.PartitionSpecs[i].SpecId == m.DefaultSpecId {
+			return &m.PartitionSpecs[i]
+		}
+	}
+	return nil
+}
+
+func (m *TableMetadata) DefaultSortOrder() *SortOrder {
+	for i := range m.SortOrders {
+		if m.SortOrders[i].OrderId == m.DefaultSortOrderId {
+			return &m.SortOrders[i]
+		}
+	}
+	return nil
+}
+
+func (c *Client) LoadTable(warehouseId, database, table string) (*LoadTableResult, error) {
+	req, err := http.NewRequest(http.MethodGet, c.tableEndpoint(warehouseId, database, table), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		clientErr, ok := err.(*ClientError)
+		if ok && clientErr.response.StatusCode == 404 {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	var result LoadTableResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CommitTable applies updates to a table, failing without changing anything when a requirement doesn't hold
+func (c *Client) CommitTable(warehouseId, database, table string, request CommitTableRequest) (*LoadTableResult, error) {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.tableEndpoint(warehouseId, database, table), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result LoadTableResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) tableEndpoint(warehouseId, database, table string) string {
+	return fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces/%s/tables/%s", c.Endpoint, warehouseId, url.PathEscape(database), url.PathEscape(table))
+}
+
+// ListTables returns the names of the tables in a database, following pagination until every page is read
+func (c *Client) ListTables(warehouseId, database string) ([]string, error) {
+	return c.listIdentifiers(fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces/%s/tables", c.Endpoint, warehouseId, url.PathEscape(database)))
+}
+
+func (c *Client) listIdentifiers(endpoint string) ([]string, error) {
+	names := make([]string, 0)
+	pageToken := ""
+	for {
+		pageEndpoint := endpoint
+		if pageToken != "" {
+			pageEndpoint += "?pageToken=" + url.QueryEscape(pageToken)
+		}
+		req, err := http.NewRequest(http.MethodGet, pageEndpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := c.doRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var page listIdentifiersResponse
+		err = json.Unmarshal(body, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, identifier := range page.Identifiers {
+			names = append(names, identifier.Name)
+		}
+
+		if page.NextPageToken == "" {
+			return names, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
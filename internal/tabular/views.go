@@ -0,0 +1,176 @@
+package tabular
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// LoadViewResult is the Iceberg REST catalog's response to loading a view
+type LoadViewResult struct {
+	MetadataLocation string            `json:"metadata-location"`
+	Metadata         ViewMetadata      `json:"metadata"`
+	Config           map[string]string `json:"config,omitempty"`
+}
+
+type ViewMetadata struct {
+	ViewUuid         string            `json:"view-uuid"`
+	FormatVersion    int               `json:"format-version"`
+	Location         string            `json:"location"`
+	CurrentVersionId int               `json:"current-version-id"`
+	Versions         []ViewVersion     `json:"versions"`
+	Schemas          []Schema          `json:"schemas"`
+	Properties       map[string]string `json:"properties"`
+}
+
+type ViewVersion struct {
+	VersionId        int                  `json:"version-id"`
+	SchemaId         int                  `json:"schema-id"`
+	TimestampMs      int64                `json:"timestamp-ms"`
+	Summary          map[string]string    `json:"summary"`
+	Representations  []ViewRepresentation `json:"representations"`
+	DefaultCatalog   *string              `json:"default-catalog,omitempty"`
+	DefaultNamespace []string             `json:"default-namespace"`
+}
+
+type ViewRepresentation struct {
+	Type    string `json:"type"`
+	Sql     string `json:"sql"`
+	Dialect string `json:"dialect"`
+}
+
+type CreateViewRequest struct {
+	Name        string            `json:"name"`
+	Location    string            `json:"location,omitempty"`
+	Schema      Schema            `json:"schema"`
+	ViewVersion ViewVersion       `json:"view-version"`
+	Properties  map[string]string `json:"properties"`
+}
+
+type CommitViewRequest struct {
+	Requirements []CatalogRequirement `json:"requirements"`
+	Updates      []CatalogUpdate      `json:"updates"`
+}
+
+// ListViews returns the names of the views in a database, following pagination until every page is read
+func (c *Client) ListViews(warehouseId, database string) ([]string, error) {
+	return c.listIdentifiers(fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces/%s/views", c.Endpoint, warehouseId, url.PathEscape(database)))
+}
+
+func (c *Client) LoadView(warehouseId, database, view string) (*LoadViewResult, error) {
+	req, err := http.NewRequest(http.MethodGet, c.viewEndpoint(warehouseId, database, view), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		clientErr, ok := err.(*ClientError)
+		if ok && clientErr.response.StatusCode == 404 {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	var result LoadViewResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) viewEndpoint(warehouseId, database, view string) string {
+	return fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces/%s/views/%s", c.Endpoint, warehouseId, url.PathEscape(database), url.PathEscape(view))
+}
+
+func (c *Client) CreateView(warehouseId, database string, request CreateViewRequest) (*LoadViewResult, error) {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("%s/ws/v1/ice/warehouses/%s/namespaces/%s/views", c.Endpoint, warehouseId, url.PathEscape(database)),
+		bytes.NewReader(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result LoadViewResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CommitView applies updates to a view, failing without changing anything when a requirement doesn't hold
+func (c *Client) CommitView(warehouseId, database, view string, request CommitViewRequest) (*LoadViewResult, error) {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.viewEndpoint(warehouseId, database, view), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result LoadViewResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (c *Client) DropView(warehouseId, database, view string) (err error) {
+	req, err := http.NewRequest(http.MethodDelete, c.viewEndpoint(warehouseId, database, view), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return
+}
+
+// CurrentVersion returns the view's current version, or nil when the metadata doesn't have it
+func (m *ViewMetadata) CurrentVersion() *ViewVersion {
+	for i := range m.Versions {
+		if m.Versions[i].VersionId == m.CurrentVersionId {
+			return &m.Versions[i]
+		}
+	}
+	return nil
+}
+
+// Schema returns the schema with the given id, or nil when the metadata doesn't have it
+func (m *ViewMetadata) Schema(schemaId int) *Schema {
+	for i := range m.Schemas {
+		if m.Schemas[i].SchemaId == schemaId {
+			return &m.Schemas[i]
+		}
+	}
+	return nil
+}